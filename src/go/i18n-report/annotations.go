@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+)
+
+// parseAnnotations extracts "@name value" annotations from a YAML head
+// comment as loaded by loadYAMLWithComments. Continuation lines (indented
+// comment lines following an annotation) are appended to its value. Lines
+// that are not part of an annotation are ignored.
+func parseAnnotations(comment string) map[string]string {
+	annotations := make(map[string]string)
+	current := ""
+	for _, line := range strings.Split(comment, "\n") {
+		text := strings.TrimPrefix(strings.TrimSpace(line), "#")
+		if strings.HasPrefix(text, " @") || strings.HasPrefix(text, "@") {
+			text = strings.TrimSpace(text)[1:]
+			name, value, _ := strings.Cut(text, " ")
+			current = name
+			annotations[name] = strings.TrimSpace(value)
+			continue
+		}
+		if current != "" && strings.HasPrefix(text, "  ") && strings.TrimSpace(text) != "" {
+			annotations[current] = strings.TrimSpace(annotations[current] + " " + strings.TrimSpace(text))
+			continue
+		}
+		current = ""
+	}
+	return annotations
+}
+
+// noTranslateTerms returns the comma-separated terms of a @no-translate
+// annotation value. An explanation after a dash ("containerd — runtime
+// name") is dropped; an annotation with only an explanation lists no
+// terms.
+func noTranslateTerms(value string) []string {
+	for _, sep := range []string{"—", " - "} {
+		if idx := strings.Index(value, sep); idx >= 0 {
+			value = value[:idx]
+		}
+	}
+	var terms []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
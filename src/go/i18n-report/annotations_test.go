@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	comment := "# @context Preferences > Container Engine\n" +
+		"# @meaning The OCI runtime,\n" +
+		"#   not a JavaScript engine\n" +
+		"# plain remark\n" +
+		"# @no-translate containerd, moby"
+	got := parseAnnotations(comment)
+	want := map[string]string{
+		"context":      "Preferences > Container Engine",
+		"meaning":      "The OCI runtime, not a JavaScript engine",
+		"no-translate": "containerd, moby",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNoTranslateTerms(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"containerd, moby", "containerd|moby"},
+		{"sudo — Unix command name", "sudo"},
+		{"— Unix command name", ""},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		if got := strings.Join(noTranslateTerms(tc.value), "|"); got != tc.want {
+			t.Errorf("noTranslateTerms(%q) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
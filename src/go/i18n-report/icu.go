@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// icuArgumentPattern matches the start of an ICU argument with a type,
+// such as "{count," in "{count, plural, ...}". Values without one use at
+// most simple {name} interpolation and are not validated as ICU.
+var icuArgumentPattern = regexp.MustCompile(`\{\s*\w+\s*,`)
+
+// icuPluralSelector matches a valid plural or selectordinal selector:
+// a CLDR plural category or an exact "=N" match.
+var icuPluralSelector = regexp.MustCompile(`^(zero|one|two|few|many|other|=\d+)$`)
+
+// icuTypes lists the ICU argument types a formatter understands.
+var icuTypes = map[string]bool{
+	"plural":        true,
+	"select":        true,
+	"selectordinal": true,
+	"number":        true,
+	"date":          true,
+	"time":          true,
+	"spellout":      true,
+	"ordinal":       true,
+	"duration":      true,
+}
+
+// literalInterpolation matches vue-i18n literal interpolation such as
+// "{'{'}", which renders the quoted text and is not an ICU argument.
+var literalInterpolation = regexp.MustCompile(`^\{'[^']*'\}`)
+
+// isICUMessage reports whether a value uses ICU argument syntax.
+func isICUMessage(s string) bool {
+	return icuArgumentPattern.MatchString(s)
+}
+
+// icuParser is a lightweight ICU MessageFormat validator. It checks
+// brace balance, argument names and types, and plural and select
+// branches, without building a message tree.
+type icuParser struct {
+	s      string
+	pos    int
+	errors []string
+}
+
+// icuErrors returns the ICU syntax errors in s, in order of appearance.
+func icuErrors(s string) []string {
+	p := &icuParser{s: s}
+	p.message(false)
+	return p.errors
+}
+
+func (p *icuParser) errorf(format string, args ...any) {
+	p.errors = append(p.errors, fmt.Sprintf(format, args...))
+}
+
+// message parses literal text and arguments up to the end of input or,
+// when nested, up to the "}" closing a branch, which is not consumed.
+func (p *icuParser) message(nested bool) {
+	for p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case '\'':
+			p.quoted()
+		case '{':
+			if m := literalInterpolation.FindString(p.s[p.pos:]); m != "" {
+				p.pos += len(m)
+				continue
+			}
+			p.pos++
+			if !p.argument() {
+				return
+			}
+		case '}':
+			if nested {
+				return
+			}
+			p.errorf("unmatched } at offset %d", p.pos)
+			p.pos++
+		default:
+			p.pos++
+		}
+	}
+}
+
+// quoted skips an apostrophe and, when it starts ICU quoting before "{",
+// "}", or "#", the quoted text. A doubled apostrophe is one literal
+// apostrophe, and any other apostrophe is literal too.
+func (p *icuParser) quoted() {
+	p.pos++
+	if p.pos >= len(p.s) {
+		return
+	}
+	switch p.s[p.pos] {
+	case '\'':
+		p.pos++
+	case '{', '}', '#':
+		if end := strings.IndexByte(p.s[p.pos:], '\''); end >= 0 {
+			p.pos += end + 1
+		} else {
+			p.pos = len(p.s)
+		}
+	}
+}
+
+// argument parses an argument after its opening "{" through its closing
+// "}". It returns false when the input ends first, since nothing after an
+// unclosed argument can be checked.
+func (p *icuParser) argument() bool {
+	open := p.pos - 1
+	name, delim, ok := p.until(",}")
+	if !ok {
+		p.errorf("unclosed argument {%s", strings.TrimSpace(name))
+		return false
+	}
+	name = strings.TrimSpace(name)
+	if !placeholderName.MatchString(name) {
+		p.errorf("invalid argument name %q", name)
+	}
+	if delim == '}' {
+		return true
+	}
+
+	argType, delim, ok := p.until(",}")
+	if !ok {
+		p.errorf("unclosed argument {%s", name)
+		return false
+	}
+	argType = strings.TrimSpace(argType)
+	if !icuTypes[argType] {
+		p.errorf("{%s}: unknown argument type %q", name, argType)
+		// Skip the body, whatever its shape.
+		end := matchingBrace(p.s, open)
+		if end < 0 {
+			p.pos = len(p.s)
+			return false
+		}
+		p.pos = end + 1
+		return true
+	}
+	if delim == '}' {
+		if argType == "plural" || argType == "select" || argType == "selectordinal" {
+			p.errorf("{%s, %s}: no branches", name, argType)
+		}
+		return true
+	}
+
+	switch argType {
+	case "plural", "select", "selectordinal":
+		return p.branches(name, argType)
+	}
+	// A number, date, or time style runs to the closing brace.
+	if _, _, ok := p.until("}"); !ok {
+		p.errorf("unclosed argument {%s", name)
+		return false
+	}
+	return true
+}
+
+// branches parses the "selector {message}" pairs of a plural or select
+// argument through its closing "}".
+func (p *icuParser) branches(name, argType string) bool {
+	seen := make(map[string]bool)
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.s) {
+			p.errorf("unclosed argument {%s", name)
+			return false
+		}
+		if p.s[p.pos] == '}' {
+			p.pos++
+			break
+		}
+		start := p.pos
+		for p.pos < len(p.s) && !strings.ContainsRune(" \t\n{}", rune(p.s[p.pos])) {
+			p.pos++
+		}
+		selector := p.s[start:p.pos]
+		if argType != "select" && strings.HasPrefix(selector, "offset:") {
+			continue
+		}
+		if selector == "" {
+			p.errorf("{%s, %s}: missing selector", name, argType)
+		} else if argType != "select" && !icuPluralSelector.MatchString(selector) {
+			p.errorf("{%s, %s}: invalid selector %q", name, argType, selector)
+		} else if seen[selector] {
+			p.errorf("{%s, %s}: duplicate selector %q", name, argType, selector)
+		}
+		seen[selector] = true
+
+		p.skipSpace()
+		if p.pos >= len(p.s) || p.s[p.pos] != '{' {
+			p.errorf("{%s, %s}: selector %q has no message", name, argType, selector)
+			if _, _, ok := p.until("}"); !ok {
+				return false
+			}
+			return true
+		}
+		p.pos++
+		p.message(true)
+		if p.pos >= len(p.s) {
+			p.errorf("{%s, %s}: unclosed message for %q", name, argType, selector)
+			return false
+		}
+		p.pos++
+	}
+	if !seen["other"] {
+		p.errorf("{%s, %s}: missing other", name, argType)
+	}
+	return true
+}
+
+// until consumes input up to and including the first byte in delims,
+// returning the text before it and the delimiter. It reports false when
+// the input ends first, or when a "{" comes first: an argument header or
+// style cannot contain one, so the rest of the input is unparseable.
+func (p *icuParser) until(delims string) (string, byte, bool) {
+	start := p.pos
+	i := strings.IndexAny(p.s[p.pos:], delims+"{")
+	if i < 0 || p.s[p.pos+i] == '{' {
+		p.pos = len(p.s)
+		return p.s[start:], 0, false
+	}
+	p.pos += i + 1
+	return p.s[start : p.pos-1], p.s[p.pos-1], true
+}
+
+func (p *icuParser) skipSpace() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestICUErrors(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"{count, plural, one {# item} other {# items}}", nil},
+		{"Delete {count} {count, plural, one {image} other {images}}?", nil},
+		{"{pages, plural,\n=0 {No Items}\n=1 {{count} {count, plural, =1 {Item} other {Items}}}\nother {{from} - {to} of {count} Items}}", nil},
+		{"{gender, select, male {He} female {She} other {They}} don't", nil},
+		{"{n, plural, offset:1 one {you} other {you and # others}}", nil},
+		{"{when, date, short} {amount, number, ::currency/EUR}", nil},
+		{"'{literal}' {count, plural, other {{'{'}#}}", nil},
+		{"{count, plural, one {# item}}", []string{"{count, plural}: missing other"}},
+		{"{count, plural, one {# item} other {# items}", []string{"unclosed argument {count"}},
+		{"{count, plural, one {# item} other {# items}}}", []string{"unmatched } at offset 45"}},
+		{"{count, plurl, one {# item} other {# items}}", []string{`{count}: unknown argument type "plurl"`}},
+		{"{count, plural, eins {# item} other {# items}}", []string{`{count, plural}: invalid selector "eins"`}},
+		{"{count, plural, one {a} one {b} other {c}}", []string{`{count, plural}: duplicate selector "one"`}},
+		{"{count, plural, one # item other {# items}}", []string{`{count, plural}: selector "one" has no message`}},
+		{"{count, plural}", []string{"{count, plural}: no branches"}},
+	}
+	for _, tt := range tests {
+		if got := icuErrors(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("icuErrors(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
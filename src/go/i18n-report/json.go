@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// isJSONFile reports whether a translation file is nested JSON rather
+// than YAML, judging by its extension.
+func isJSONFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// loadJSONFile decodes a nested JSON translation file. Numbers are kept
+// as json.Number so that their text survives flattening unchanged.
+func loadJSONFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return raw, nil
+}
+
+// flattenJSONEntries records the leaves of a decoded JSON value under key
+// as merge entries, the JSON counterpart of flattenChildWithComments.
+// JSON has no comments; numbers and booleans keep their YAML type tag so
+// writers leave them unquoted, and null leaves are dropped.
+func flattenJSONEntries(key string, v interface{}, result map[string]mergeEntry) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			childKey := k
+			if key != "" {
+				childKey = key + "." + k
+			}
+			flattenJSONEntries(childKey, child, result)
+		}
+	case []interface{}:
+		for i, item := range val {
+			flattenJSONEntries(key+"."+strconv.Itoa(i), item, result)
+		}
+	case json.Number:
+		tag := "!!float"
+		if _, err := val.Int64(); err == nil {
+			tag = "!!int"
+		}
+		result[key] = mergeEntry{key: key, value: val.String(), tag: tag}
+	case bool:
+		result[key] = mergeEntry{key: key, value: strconv.FormatBool(val), tag: "!!bool"}
+	case nil:
+		// vue-i18n falls back for a null message as for an absent key.
+	default:
+		result[key] = mergeEntry{key: key, value: fmt.Sprintf("%v", val)}
+	}
+}
+
+// flattenJSON flattens a decoded JSON translation file into dotted keys,
+// with the same values and null handling as loadYAMLWithComments.
+func flattenJSON(raw map[string]interface{}) map[string]string {
+	entries := make(map[string]mergeEntry)
+	flattenJSONEntries("", raw, entries)
+	result := make(map[string]string, len(entries))
+	for k, e := range entries {
+		result[k] = e.value
+	}
+	return result
+}
+
+// writeNestedJSON writes entries as a nested JSON object, the JSON
+// counterpart of writeNestedYAML. Keys follow the same order, and parents
+// whose children are consecutive indexes become arrays. Comments and the
+// YAML-only layout options are ignored.
+func writeNestedJSON(w *strings.Builder, entries []mergeEntry, opts yamlWriteOptions) {
+	sortEntries(entries, opts.order)
+	keys := make([]string, 0, len(entries))
+	for _, e := range entries {
+		keys = append(keys, e.key)
+	}
+	seqs := sequenceParents(keys)
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	parents := map[string]*yaml.Node{"": root}
+	for _, e := range entries {
+		parts := strings.Split(e.key, ".")
+		parent := root
+		for j, seg := range parts[:len(parts)-1] {
+			prefix := strings.Join(parts[:j+1], ".")
+			node, ok := parents[prefix]
+			if !ok {
+				node = &yaml.Node{Kind: yaml.MappingNode}
+				if seqs[prefix] {
+					node.Kind = yaml.SequenceNode
+				}
+				appendJSONChild(parent, seg, node)
+				parents[prefix] = node
+			}
+			parent = node
+		}
+		leaf := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.value}
+		if isTypedScalarTag(e.tag) {
+			leaf.Tag = e.tag
+		}
+		appendJSONChild(parent, parts[len(parts)-1], leaf)
+	}
+
+	writeJSONNode(w, root, 0)
+	w.WriteString("\n")
+}
+
+// appendJSONChild adds child to a mapping under name, or to a sequence.
+func appendJSONChild(parent *yaml.Node, name string, child *yaml.Node) {
+	if parent.Kind == yaml.MappingNode {
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name})
+	}
+	parent.Content = append(parent.Content, child)
+}
+
+// writeJSONNode writes a YAML node tree as indented JSON. Scalars tagged
+// as numbers, booleans, or null are written bare; everything else is a
+// string. Used for JSON translation files, which are parsed with the YAML
+// decoder (JSON being a subset of YAML) when their structure is edited.
+func writeJSONNode(w *strings.Builder, node *yaml.Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			writeJSONNode(w, node.Content[0], depth)
+		}
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			w.WriteString("{}")
+			return
+		}
+		w.WriteString("{\n")
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			if i > 0 {
+				w.WriteString(",\n")
+			}
+			w.WriteString(indent + "  ")
+			w.WriteString(jsonString(node.Content[i].Value))
+			w.WriteString(": ")
+			writeJSONNode(w, node.Content[i+1], depth+1)
+		}
+		w.WriteString("\n" + indent + "}")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			w.WriteString("[]")
+			return
+		}
+		w.WriteString("[\n")
+		for i, item := range node.Content {
+			if i > 0 {
+				w.WriteString(",\n")
+			}
+			w.WriteString(indent + "  ")
+			writeJSONNode(w, item, depth+1)
+		}
+		w.WriteString("\n" + indent + "]")
+	case yaml.AliasNode:
+		writeJSONNode(w, node.Alias, depth)
+	default:
+		switch tag := node.ShortTag(); {
+		case isTypedScalarTag(tag):
+			w.WriteString(node.Value)
+		case tag == "!!null":
+			w.WriteString("null")
+		default:
+			w.WriteString(jsonString(node.Value))
+		}
+	}
+}
+
+// jsonString returns s as a JSON string literal, leaving HTML characters
+// unescaped since translations often contain markup.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const jsonLocale = `{
+  "nav": {
+    "home": "Startseite",
+    "about": "Über <b>uns</b>"
+  },
+  "steps": [
+    "Eins",
+    "Zwei"
+  ],
+  "limit": 10,
+  "beta": true
+}
+`
+
+func TestLoadJSONLocale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	os.WriteFile(path, []byte(jsonLocale), 0644)
+
+	flat, err := loadYAMLFlat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"nav.home":  "Startseite",
+		"nav.about": "Über <b>uns</b>",
+		"steps.0":   "Eins",
+		"steps.1":   "Zwei",
+		"limit":     "10",
+		"beta":      "true",
+	}
+	if !reflect.DeepEqual(flat, want) {
+		t.Errorf("loadYAMLFlat = %v, want %v", flat, want)
+	}
+
+	entries, err := loadYAMLWithComments(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entries["limit"]; got.value != "10" || got.tag != "!!int" {
+		t.Errorf("limit entry = %+v, want value 10 tagged !!int", got)
+	}
+	if got := entries["nav.home"]; got.value != "Startseite" || got.tag != "" || got.comment != "" {
+		t.Errorf("nav.home entry = %+v", got)
+	}
+}
+
+func TestLoadJSONLocaleNull(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	os.WriteFile(path, []byte(`{"nav": {"home": "Startseite", "about": null}}`), 0644)
+
+	// A null value is an untranslated key, not the string "<nil>".
+	flat, err := loadYAMLFlat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"nav.home": "Startseite"}; !reflect.DeepEqual(flat, want) {
+		t.Errorf("loadYAMLFlat = %v, want %v", flat, want)
+	}
+	entries, err := loadYAMLWithComments(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := entries["nav.about"]; found || len(entries) != 1 {
+		t.Errorf("entries = %+v, want only nav.home", entries)
+	}
+}
+
+func TestWriteNestedJSONRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	os.WriteFile(path, []byte(jsonLocale), 0644)
+
+	entries, err := loadYAMLWithComments(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := loadYAMLKeyOrder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list []mergeEntry
+	for _, e := range entries {
+		list = append(list, e)
+	}
+	var buf strings.Builder
+	writeNestedJSON(&buf, list, yamlWriteOptions{order: order})
+	if buf.String() != jsonLocale {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), jsonLocale)
+	}
+}
+
+func TestRemoveKeysFromJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.json")
+	os.WriteFile(path, []byte(jsonLocale), 0644)
+
+	removed, err := removeKeysFromFile(path, map[string]bool{"nav.home": true, "nav.about": true, "beta": true}, removeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"beta", "nav.about", "nav.home"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+	data, _ := os.ReadFile(path)
+	want := `{
+  "steps": [
+    "Eins",
+    "Zwei"
+  ],
+  "limit": 10
+}
+`
+	if string(data) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestReportMergeJSON(t *testing.T) {
+	dir := writeCheckFixture(t)
+	transDir := filepath.Join(dir, translationsDir)
+	os.Remove(filepath.Join(transDir, "de.yaml"))
+	os.WriteFile(filepath.Join(transDir, "de.json"), []byte(`{"nav": {"home": "Startseite"}}`), 0644)
+	input := filepath.Join(t.TempDir(), "input.txt")
+	os.WriteFile(input, []byte("nav.about=Über\n"), 0644)
+
+	if err := reportMerge(dir, "de", []string{input}, mergeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(transDir, "de.json"))
+	want := `{
+  "nav": {
+    "about": "Über",
+    "home": "Startseite"
+  }
+}
+`
+	if string(data) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", data, want)
+	}
+	if _, err := os.Stat(filepath.Join(transDir, "de.yaml")); err == nil {
+		t.Error("merge created de.yaml next to de.json")
+	}
+}
@@ -2,7 +2,7 @@
 //
 // Usage:
 //
-//	i18n-report <subcommand> [flags] [args]
+//	i18n-report [-v] <subcommand> [flags] [args]
 //
 // Run "i18n-report" with no arguments for a list of subcommands.
 package main
@@ -10,28 +10,75 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
 var subcommands = map[string]func([]string) error{
-	"unused":       runUnused,
-	"missing":      runMissing,
-	"stale":        runStale,
-	"translate":    runTranslate,
-	"merge":        runMerge,
-	"untranslated": runUntranslated,
-	"references":   runReferences,
-	"dynamic":      runDynamic,
-	"check":        runCheck,
-	"remove":       runRemove,
+	"unused":         runUnused,
+	"missing":        runMissing,
+	"stale":          runStale,
+	"translate":      runTranslate,
+	"merge":          runMerge,
+	"export":         runExport,
+	"scaffold":       runScaffold,
+	"migrate-quotes": runMigrateQuotes,
+	"normalize":      runNormalize,
+	"untranslated":   runUntranslated,
+	"references":     runReferences,
+	"dynamic":        runDynamic,
+	"unresolved":     runUnresolved,
+	"undefined":      runUndefined,
+	"concat":         runConcat,
+	"duplicates":     runDuplicates,
+	"casing":         runCasing,
+	"key-names":      runKeyNames,
+	"glossary":       runGlossary,
+	"lines":          runLines,
+	"empty":          runEmpty,
+	"whitespace":     runWhitespace,
+	"length":         runLength,
+	"braces":         runBraces,
+	"markup":         runMarkup,
+	"placeholders":   runPlaceholders,
+	"icu":            runICU,
+	"no-translate":   runNoTranslate,
+	"most-missing":   runMostMissing,
+	"uncovered":      runUncovered,
+	"wasted":         runWasted,
+	"validate":       runValidate,
+	"stats":          runStats,
+	"check":          runCheck,
+	"remove":         runRemove,
+	"prune":          runPrune,
 }
 
 func main() {
-	if len(os.Args) < 2 {
+	args := os.Args[1:]
+flags:
+	for len(args) > 0 {
+		switch {
+		case args[0] == "-v" || args[0] == "--verbose":
+			verbose = true
+		case args[0] == "-o" || args[0] == "--output":
+			if len(args) < 2 {
+				fmt.Fprintf(os.Stderr, "Error: %s requires a file name\n", args[0])
+				os.Exit(1)
+			}
+			outputPath = args[1]
+			args = args[1:]
+		case strings.HasPrefix(args[0], "--output="):
+			outputPath = strings.TrimPrefix(args[0], "--output=")
+		default:
+			break flags
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 {
 		printUsage()
 		os.Exit(1)
 	}
 
-	name := os.Args[1]
+	name := args[0]
 	if name == "-h" || name == "--help" || name == "help" {
 		printUsage()
 		return
@@ -44,26 +91,65 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := run(os.Args[2:]); err != nil {
+	args, path, err := splitOutputFlag(args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if path != "" {
+		outputPath = path
+	}
+	if err := runWithOutput(run, args); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 func printUsage() {
-	fmt.Fprintln(os.Stderr, `Usage: i18n-report <subcommand> [flags] [args]
+	fmt.Fprintln(os.Stderr, `Usage: i18n-report [-v] [-o file] <subcommand> [flags] [args]
 
 Subcommands:
-  unused        Keys in en-us.yaml not referenced in source code
-  missing       Keys in en-us.yaml absent from a target locale
-  stale         Keys in a locale file absent from en-us.yaml
-  translate     Keys missing from a locale, with English values
-  merge         Read flat translations, write nested YAML locale file
-  remove        Remove keys from translation files (stdin or --stale)
-  untranslated  Hardcoded English strings in Vue/TS files (heuristic)
-  references    Where each en-us.yaml key is used (file:line)
-  dynamic       Template literal patterns that reference keys dynamically
-  check         Lint check: unused + stale + missing translations
+  unused          Keys in en-us.yaml not referenced in source code
+  missing         Keys in en-us.yaml absent from a target locale
+  stale           Keys in a locale file absent from en-us.yaml
+  translate       Keys missing from a locale, with English values
+  merge           Read flat translations, write nested YAML locale file
+  export          Write a locale as a flat JSON key/value map
+  scaffold        Create a new locale file with English values to translate
+  migrate-quotes  Rewrite a locale file with single-quoted values
+  normalize       Rewrite a locale file with minimal, uniform quoting
+  remove          Remove keys from translation files (files, stdin, or --stale)
+  prune           Remove keys marked @deprecated from all translation files
+  untranslated    Hardcoded English strings in Vue/TS files (heuristic)
+  references      Where each en-us.yaml key is used (file:line)
+  dynamic         Template literal patterns that reference keys dynamically
+  unresolved      t() calls whose key is a variable the scanner cannot see
+  undefined       Keys used in source but missing from en-us.yaml, with suggestions
+  concat          Source lines joining several t() calls with +
+  duplicates      English values defined under more than one key
+  casing          Keys in en-us.yaml that differ only in case
+  key-names       Keys in en-us.yaml breaking the camelCase naming convention
+  glossary        English terms translated inconsistently within a locale
+  lines           Multiline values whose translation has a different line count
+  empty           Keys whose value is empty or whitespace only
+  whitespace      Values with leading, trailing, or doubled whitespace
+  length          Translations much longer than their English source
+  braces          English values using {{name}} instead of {name} interpolation
+  markup          Translations whose HTML tags differ from English
+  placeholders    Translations using placeholder names English does not define
+  icu             Malformed ICU plural/select messages and argument mismatches
+  no-translate    Translations that altered terms marked @no-translate
+  most-missing    Used keys ranked by how many locales lack them
+  uncovered       Keys in en-us.yaml that no other locale defines
+  wasted          Unused keys that a locale still translates
+  validate        Unquoted values YAML reads as booleans, nulls, or numbers
+  stats           Summary counts of keys, references, and locale completeness
+  check           Lint check: unused + stale + missing translations
+
+Global flags:
+  -v, --verbose   Log file counts, scan progress, and phase timings to stderr
+  -o, --output    Write the report to this file instead of stdout; may
+                  also follow the subcommand with its other flags
 
 Run "i18n-report <subcommand> -h" for subcommand-specific flags.`)
 }
@@ -3,15 +3,161 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
-// outputStrings prints a list of strings in text or JSON format.
+// outputPath is the file that receives the primary report instead of
+// stdout. It is set by the -o/--output flag, given before the subcommand
+// or among its flags; stderr summaries and warnings are not redirected.
+var outputPath string
+
+// splitOutputFlag removes -o/--output from a subcommand's arguments, so
+// that it can follow the subcommand like any other flag, and returns the
+// remaining arguments and the file name, if any. Arguments after "--"
+// are left alone.
+func splitOutputFlag(args []string) ([]string, string, error) {
+	var rest []string
+	path := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		if name != "-o" && name != "--output" && name != "-output" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return nil, "", fmt.Errorf("%s requires a file name", arg)
+			}
+			i++
+			value = args[i]
+		}
+		path = value
+	}
+	return rest, path, nil
+}
+
+// lazyFile is a writer that creates its file on the first write, so the
+// file is left alone until there is something to put in it.
+type lazyFile struct {
+	path string
+	f    *os.File
+}
+
+func (l *lazyFile) Write(p []byte) (int, error) {
+	if l.f == nil {
+		f, err := os.Create(l.path)
+		if err != nil {
+			return 0, err
+		}
+		l.f = f
+	}
+	return l.f.Write(p)
+}
+
+// Close creates the file if nothing was written, so that a command with
+// an empty report still replaces the previous one, then closes it.
+func (l *lazyFile) Close() error {
+	if _, err := l.Write(nil); err != nil {
+		return err
+	}
+	return l.f.Close()
+}
+
+// redirectStdout points os.Stdout at a pipe feeding path, so every report
+// writes there without knowing. path is only created once the first byte
+// arrives: a command that stops on an invalid flag or option before
+// printing anything leaves an existing report untouched. The returned
+// function restores stdout and waits for the copy to finish; with keep,
+// the file is also written when the command printed nothing.
+func redirectStdout(path string) (func(keep bool) error, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	out := &lazyFile{path: path}
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(out, r)
+		if err != nil {
+			// Keep draining so the command never blocks on a full pipe.
+			io.Copy(io.Discard, r)
+		}
+		r.Close()
+		done <- err
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	return func(keep bool) error {
+		os.Stdout = stdout
+		w.Close()
+		err := <-done
+		if out.f != nil || (keep && err == nil) {
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// runWithOutput runs a subcommand, sending its stdout to outputPath when
+// one is set. The file is kept when the command fails after printing:
+// check, for one, prints its full table before returning the failure.
+func runWithOutput(run func([]string) error, args []string) error {
+	if outputPath == "" {
+		return run(args)
+	}
+	restore, err := redirectStdout(outputPath)
+	if err != nil {
+		return err
+	}
+	err = run(args)
+	if closeErr := restore(err == nil); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// checkFormat returns an error unless format is one of supported. Commands
+// call it before doing any work, so a typo does not fall back to text.
+func checkFormat(format string, supported ...string) error {
+	if slices.Contains(supported, format) {
+		return nil
+	}
+	return fmt.Errorf("unsupported format %q; want one of: %s", format, strings.Join(supported, ", "))
+}
+
+// outputStrings prints a list of strings in text, JSON, or YAML format.
 func outputStrings(items []string, format, label string) error {
-	if format == "json" {
+	if err := checkFormat(format, "text", "json", "yaml"); err != nil {
+		return err
+	}
+	switch format {
+	case "json":
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		return enc.Encode(items)
+	case "yaml":
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if items == nil {
+			items = []string{}
+		}
+		if err := enc.Encode(items); err != nil {
+			return err
+		}
+		return enc.Close()
 	}
 
 	if len(items) == 0 {
@@ -25,3 +171,28 @@ func outputStrings(items []string, format, label string) error {
 	}
 	return nil
 }
+
+// keyValueEntry is a key with its English and translated values, used by
+// the --rich JSON output of missing and stale. Either value is empty when
+// the key is absent from that file.
+type keyValueEntry struct {
+	Key         string `json:"key"`
+	EnValue     string `json:"enValue"`
+	LocaleValue string `json:"localeValue"`
+}
+
+// keyValues looks up the English and locale values of each key.
+func keyValues(keys []string, enKeys, localeKeys map[string]string) []keyValueEntry {
+	entries := make([]keyValueEntry, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, keyValueEntry{Key: k, EnValue: enKeys[k], LocaleValue: localeKeys[k]})
+	}
+	return entries
+}
+
+// outputKeyValues prints key/value entries as JSON.
+func outputKeyValues(entries []keyValueEntry) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReportStaleRich(t *testing.T) {
+	dir := writeCheckFixture(t)
+
+	out, err := captureStdout(t, func() error {
+		return reportStale(dir, "de", "json", staleOptions{rich: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []keyValueEntry
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	want := []keyValueEntry{{Key: "nav.old", LocaleValue: "Alt"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestReportMissingRich(t *testing.T) {
+	dir := writeCheckFixture(t)
+
+	out, err := captureStdout(t, func() error {
+		return reportMissing(dir, "de", "json", missingOptions{rich: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []keyValueEntry
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	want := []keyValueEntry{{Key: "nav.about", EnValue: "About"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// Without --rich the output stays a bare array of keys.
+	out, err = captureStdout(t, func() error {
+		return reportMissing(dir, "de", "json", missingOptions{})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	if err := json.Unmarshal([]byte(out), &keys); err != nil || len(keys) != 1 {
+		t.Errorf("expected bare array, got %s", out)
+	}
+}
+
+func TestOutputStringsYAML(t *testing.T) {
+	out, err := captureStdout(t, func() error {
+		return outputStrings([]string{"nav.about", "nav.home"}, "yaml", "keys")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- nav.about\n- nav.home\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+
+	out, err = captureStdout(t, func() error {
+		return outputStrings(nil, "yaml", "keys")
+	})
+	if err != nil || out != "[]\n" {
+		t.Errorf("empty list: got %q, %v", out, err)
+	}
+
+	if err := outputStrings(nil, "xml", "keys"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestCheckFormat(t *testing.T) {
+	if err := checkFormat("json", "text", "json"); err != nil {
+		t.Errorf("json: unexpected error %v", err)
+	}
+	err := checkFormat("xml", "text", "json")
+	if err == nil || err.Error() != `unsupported format "xml"; want one of: text, json` {
+		t.Errorf("xml: got %v", err)
+	}
+}
+
+func TestRunWithOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.txt")
+	outputPath = path
+	t.Cleanup(func() { outputPath = "" })
+	stdout := os.Stdout
+
+	run := func([]string) error {
+		fmt.Println("report line")
+		return errors.New("checks failed")
+	}
+	if err := runWithOutput(run, nil); err == nil || err.Error() != "checks failed" {
+		t.Errorf("got error %v, want the command's error", err)
+	}
+	if os.Stdout != stdout {
+		t.Error("stdout was not restored")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "report line\n" {
+		t.Errorf("file contains %q", data)
+	}
+}
+
+func TestRunWithOutputKeepsReportOnInvalidInvocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(path, []byte("previous report\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outputPath = path
+	t.Cleanup(func() { outputPath = "" })
+
+	// A command rejecting its options prints nothing to stdout.
+	run := func([]string) error { return errors.New("--locale is required") }
+	if err := runWithOutput(run, nil); err == nil {
+		t.Fatal("expected the command's error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "previous report\n" {
+		t.Errorf("report overwritten with %q", data)
+	}
+
+	// A successful command with nothing to print still replaces it.
+	if err := runWithOutput(func([]string) error { return nil }, nil); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Errorf("file contains %q, want it emptied", data)
+	}
+}
+
+func TestSplitOutputFlag(t *testing.T) {
+	tests := []struct {
+		args []string
+		rest []string
+		path string
+	}{
+		{[]string{"--locale=de"}, []string{"--locale=de"}, ""},
+		{[]string{"--locale=de", "--output", "check.txt"}, []string{"--locale=de"}, "check.txt"},
+		{[]string{"-o", "check.txt", "--locale=de"}, []string{"--locale=de"}, "check.txt"},
+		{[]string{"--output=check.txt", "--format=junit"}, []string{"--format=junit"}, "check.txt"},
+		{[]string{"-output=check.txt"}, nil, "check.txt"},
+		{[]string{"key", "--", "-o", "file"}, []string{"key", "--", "-o", "file"}, ""},
+	}
+	for _, tt := range tests {
+		rest, path, err := splitOutputFlag(tt.args)
+		if err != nil {
+			t.Errorf("splitOutputFlag(%q): %v", tt.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(rest, tt.rest) || path != tt.path {
+			t.Errorf("splitOutputFlag(%q) = %q, %q, want %q, %q", tt.args, rest, path, tt.rest, tt.path)
+		}
+	}
+	if _, _, err := splitOutputFlag([]string{"--locale=de", "--output"}); err == nil {
+		t.Error("expected an error for --output without a file name")
+	}
+}
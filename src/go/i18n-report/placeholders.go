@@ -0,0 +1,220 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// placeholderName matches a valid interpolation argument name.
+var placeholderName = regexp.MustCompile(`^\w+$`)
+
+// positionalName matches a positional argument name such as "0" in {0}.
+var positionalName = regexp.MustCompile(`^\d+$`)
+
+// defaultRTLLocales lists the primary language subtags of the
+// right-to-left locales whose translations are checked for positional
+// placeholder reordering when --rtl-locales is not given.
+var defaultRTLLocales = []string{"ar", "fa", "he", "ur"}
+
+// isRTLLocale reports whether a locale tag is one of the right-to-left
+// locales in rtl, or in defaultRTLLocales when rtl is nil. Tags match by
+// their primary language subtag, so that regional variants such as
+// ar-EG and he-IL count too.
+func isRTLLocale(locale string, rtl []string) bool {
+	if rtl == nil {
+		rtl = defaultRTLLocales
+	}
+	lang, _, _ := strings.Cut(locale, "-")
+	for _, l := range rtl {
+		if strings.EqualFold(l, locale) || strings.EqualFold(l, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRTLLocales splits a comma-separated --rtl-locales value.
+func parseRTLLocales(value string) []string {
+	rtl := []string{}
+	for _, l := range strings.Split(value, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			rtl = append(rtl, l)
+		}
+	}
+	return rtl
+}
+
+// placeholderNames returns the interpolation argument names used in a
+// translation value, in order of appearance. It understands both simple
+// {variable} interpolation and ICU MessageFormat arguments such as
+// {count, plural, one {# item} other {# items}}. Text inside plural and
+// select branches is scanned for nested arguments; branch selectors and
+// literal text are not reported.
+func placeholderNames(s string) []string {
+	var names []string
+	scanMessage(s, &names)
+	return names
+}
+
+// scanMessage appends the argument names of each top-level {...} group in s.
+func scanMessage(s string, names *[]string) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '{' {
+			continue
+		}
+		end := matchingBrace(s, i)
+		if end < 0 {
+			return
+		}
+		scanArgument(s[i+1:end], names)
+		i = end
+	}
+}
+
+// scanArgument records the name of a single argument body (the text
+// between its braces) and recurses into plural/select branches.
+func scanArgument(arg string, names *[]string) {
+	parts := strings.SplitN(arg, ",", 3)
+	name := strings.TrimSpace(parts[0])
+	if !placeholderName.MatchString(name) {
+		return
+	}
+	*names = append(*names, name)
+	if len(parts) < 3 {
+		return
+	}
+	switch strings.TrimSpace(parts[1]) {
+	case "plural", "select", "selectordinal":
+		// Each branch is "selector {message}"; scan only the messages.
+		branches := parts[2]
+		for i := 0; i < len(branches); i++ {
+			if branches[i] != '{' {
+				continue
+			}
+			end := matchingBrace(branches, i)
+			if end < 0 {
+				return
+			}
+			scanMessage(branches[i+1:end], names)
+			i = end
+		}
+	}
+}
+
+// matchingBrace returns the index of the '}' closing the '{' at open,
+// or -1 if the braces are unbalanced.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// doubleBracePattern matches Vue template interpolation such as
+// "{{ name }}" at the start of a string.
+var doubleBracePattern = regexp.MustCompile(`^\{\{\s*[\w.]+\s*\}\}`)
+
+// doubleBraceInterpolations returns the "{{name}}" sequences in s that
+// sit outside any ICU argument. vue-i18n renders these literally, so they
+// are almost always Vue template syntax pasted into a message. Nested
+// braces inside plural and select branches (e.g. "=1 {{count}}") are
+// valid ICU and are not reported, nor are quoted literals such as
+// "{'{'}" or "'{{name}}'".
+func doubleBraceInterpolations(s string) []string {
+	var found []string
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			// ICU quoting: skip to the closing apostrophe.
+			if end := strings.IndexByte(s[i+1:], '\''); end >= 0 {
+				i += end + 1
+			}
+			continue
+		case '{':
+		default:
+			continue
+		}
+		if m := doubleBracePattern.FindString(s[i:]); m != "" {
+			found = append(found, m)
+			i += len(m) - 1
+			continue
+		}
+		end := matchingBrace(s, i)
+		if end < 0 {
+			break
+		}
+		i = end
+	}
+	return found
+}
+
+// placeholderDiff compares the placeholder names in an English source
+// value and its translation. It returns the sorted names missing from the
+// translation and the sorted names the translation adds.
+func placeholderDiff(source, translated string) (missing, extra []string) {
+	want := make(map[string]bool)
+	for _, n := range placeholderNames(source) {
+		want[n] = true
+	}
+	have := make(map[string]bool)
+	for _, n := range placeholderNames(translated) {
+		have[n] = true
+	}
+	for n := range want {
+		if !have[n] {
+			missing = append(missing, n)
+		}
+	}
+	for n := range have {
+		if !want[n] {
+			extra = append(extra, n)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+	return missing, extra
+}
+
+// positionalOrderChanged reports whether the positional placeholders
+// ({0}, {1}, ...) appear in a different order in the translation than in
+// the English source. Named placeholders are bound by name, so reordering
+// them is harmless and they are ignored.
+func positionalOrderChanged(source, translated string) bool {
+	a := positionalSequence(source)
+	b := positionalSequence(translated)
+	if len(a) != len(b) {
+		// Missing or extra placeholders are a parity problem, not an
+		// ordering one.
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// positionalSequence returns the distinct positional placeholder names in
+// order of first appearance.
+func positionalSequence(s string) []string {
+	seen := make(map[string]bool)
+	var seq []string
+	for _, n := range placeholderNames(s) {
+		if positionalName.MatchString(n) && !seen[n] {
+			seen[n] = true
+			seq = append(seq, n)
+		}
+	}
+	return seq
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlaceholderNames(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"no placeholders", ""},
+		{"Quit {appName}", "appName"},
+		{"Error deleting {name} ({id}): {error}", "name,id,error"},
+		{"Delete {count} {count, plural, one {image} other {images}}?", "count,count"},
+		{"{pages, plural, =1 {{count} {count, plural, =1 {Item} other {Items}}} other {{from} - {to} of {count} Items}}", "pages,count,count,from,to,count"},
+		{"{ not a name }", ""},
+		{"unbalanced {name", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got := strings.Join(placeholderNames(tc.input), ",")
+			if got != tc.want {
+				t.Errorf("placeholderNames(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPlaceholderDiff(t *testing.T) {
+	missing, extra := placeholderDiff("Delete {name} from {host}", "{nom} supprimer de {host}")
+	if strings.Join(missing, ",") != "name" {
+		t.Errorf("missing = %v, want [name]", missing)
+	}
+	if strings.Join(extra, ",") != "nom" {
+		t.Errorf("extra = %v, want [nom]", extra)
+	}
+
+	missing, extra = placeholderDiff("{count, plural, one {# image} other {# images}}", "{count, plural, one {# Bild} other {# Bilder}}")
+	if len(missing) != 0 || len(extra) != 0 {
+		t.Errorf("plural branches should not be compared as placeholders: missing=%v extra=%v", missing, extra)
+	}
+}
+
+func TestPositionalOrderChanged(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		translated string
+		want       bool
+	}{
+		{"same positional order", "Copy {0} to {1}", "{0} را به {1} کپی کنید", false},
+		{"positional reordered", "Copy {0} to {1}", "به {1} کپی {0}", true},
+		{"named reordered is fine", "Copy {src} to {dest}", "به {dest} کپی {src}", false},
+		{"mixed, only named reordered", "{0}: {name} and {other}", "{0}: {other} و {name}", false},
+		{"repeated positional", "{0} of {1} ({0})", "{0} از {1}", false},
+		{"missing placeholder is not an ordering issue", "Copy {0} to {1}", "کپی {1}", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := positionalOrderChanged(tc.source, tc.translated); got != tc.want {
+				t.Errorf("positionalOrderChanged(%q, %q) = %v, want %v", tc.source, tc.translated, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsRTLLocale(t *testing.T) {
+	for locale, want := range map[string]bool{
+		"ar":    true,
+		"ar-EG": true,
+		"he-IL": true,
+		"fa-ir": true,
+		"de":    false,
+		"en-us": false,
+		"arn":   false,
+	} {
+		if got := isRTLLocale(locale, nil); got != want {
+			t.Errorf("isRTLLocale(%q) = %v, want %v", locale, got, want)
+		}
+	}
+
+	// --rtl-locales replaces the default list.
+	rtl := parseRTLLocales("ckb, dv")
+	for locale, want := range map[string]bool{
+		"ckb":    true,
+		"ckb-IR": true,
+		"dv":     true,
+		"ar":     false,
+	} {
+		if got := isRTLLocale(locale, rtl); got != want {
+			t.Errorf("isRTLLocale(%q, %q) = %v, want %v", locale, rtl, got, want)
+		}
+	}
+}
+
+func TestDoubleBraceInterpolations(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Hello {name}", ""},
+		{"Hello {{name}}", "{{name}}"},
+		{"{{ count }} items in {{folder.name}}", "{{ count }},{{folder.name}}"},
+		{"{pages, plural, =1 {{count}} other {{from} - {to}}}", ""},
+		{"Literal {'{'}{'{'}name{'}'}{'}'}", ""},
+		{"Quoted '{{name}}' text", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got := strings.Join(doubleBraceInterpolations(tc.input), ",")
+			if got != tc.want {
+				t.Errorf("doubleBraceInterpolations(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 )
 
 const translationsDir = "pkg/rancher-desktop/assets/translations"
@@ -31,3 +34,115 @@ func repoRoot() (string, error) {
 func translationsPath(root, filename string) string {
 	return filepath.Join(root, translationsDir, filename)
 }
+
+// backupFile copies path to path+".bak" before it is overwritten,
+// replacing any earlier backup. A missing path is not an error, since there
+// is nothing to lose.
+func backupFile(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".bak", data, 0644); err != nil {
+		return fmt.Errorf("writing backup of %s: %w", path, err)
+	}
+	return nil
+}
+
+// translationExts are the recognized locale file extensions, in lookup order.
+var translationExts = []string{".yaml", ".yml", ".json"}
+
+// findLocaleFile returns the path of a locale's translation file, trying
+// each of translationExts in turn. If none exists, the .yaml path is
+// returned so that readers report it as missing and writers create it. It
+// is an error for more than one to exist, since none is clearly
+// authoritative.
+func findLocaleFile(root, locale string) (string, error) {
+	var found, names []string
+	for _, ext := range translationExts {
+		path := translationsPath(root, locale+ext)
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
+			names = append(names, locale+ext)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return translationsPath(root, locale+translationExts[0]), nil
+	case 1:
+		return found[0], nil
+	}
+	if len(names) == 2 {
+		return "", fmt.Errorf("both %s and %s exist in %s; remove one", names[0], names[1], translationsDir)
+	}
+	return "", fmt.Errorf("%s all exist in %s; keep only one", strings.Join(names, ", "), translationsDir)
+}
+
+// defaultSourceLocale is the locale whose file defines the reference keys
+// and values unless --source-locale names another.
+const defaultSourceLocale = "en-us"
+
+// sourceLocaleFlag registers --source-locale. Subcommands pass its value
+// on to their report, usually in the sourceLocale field of its options.
+func sourceLocaleFlag(fs *flag.FlagSet) *string {
+	return fs.String("source-locale", defaultSourceLocale, "Locale whose file is the source of truth for keys (e.g. en-gb)")
+}
+
+// sourceLocaleOrDefault returns locale, or defaultSourceLocale when it is
+// empty, as it is in zero-valued options.
+func sourceLocaleOrDefault(locale string) string {
+	if locale == "" {
+		return defaultSourceLocale
+	}
+	return locale
+}
+
+// sourceLocalePath returns the path of the source locale file for locale,
+// or for defaultSourceLocale when locale is empty.
+func sourceLocalePath(root, locale string) (string, error) {
+	return findLocaleFile(root, sourceLocaleOrDefault(locale))
+}
+
+// localeName returns the locale code of a translation file path
+// (e.g. "de" for ".../de.yml").
+func localeName(path string) string {
+	base := filepath.Base(path)
+	for _, ext := range translationExts {
+		if strings.HasSuffix(base, ext) {
+			return strings.TrimSuffix(base, ext)
+		}
+	}
+	return base
+}
+
+// localeTagPattern matches the well-formed BCP 47 tags used as locale
+// file names: a 2-3 letter language, an optional 4-letter script, and an
+// optional 2-letter or 3-digit region, joined by hyphens (e.g. "de",
+// "en-us", "zh-hans", "es-419"). Tags are case-insensitive.
+var localeTagPattern = regexp.MustCompile(`(?i)^[a-z]{2,3}(-[a-z]{4})?(-([a-z]{2}|[0-9]{3}))?$`)
+
+// isLocaleTag reports whether s is a well-formed locale tag. Names such
+// as "en_US" or "german" are rejected, since vue-i18n would never load
+// them as the intended locale.
+func isLocaleTag(s string) bool {
+	return localeTagPattern.MatchString(s)
+}
+
+// malformedLocaleFiles returns the base names of translation files whose
+// name is not a well-formed locale tag.
+func malformedLocaleFiles(root string) ([]string, error) {
+	paths, err := findTranslationFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	var malformed []string
+	for _, p := range paths {
+		if !isLocaleTag(localeName(p)) {
+			malformed = append(malformed, filepath.Base(p))
+		}
+	}
+	return malformed, nil
+}
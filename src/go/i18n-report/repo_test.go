@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindLocaleFile(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, translationsDir)
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "de.yaml"), []byte("a: b\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "fr.yml"), []byte("a: b\n"), 0644)
+
+	for locale, want := range map[string]string{
+		"de": filepath.Join(dir, "de.yaml"),
+		"fr": filepath.Join(dir, "fr.yml"),
+		"ja": filepath.Join(dir, "ja.yaml"),
+	} {
+		got, err := findLocaleFile(root, locale)
+		if err != nil {
+			t.Fatalf("%s: %v", locale, err)
+		}
+		if got != want {
+			t.Errorf("%s: got %s, want %s", locale, got, want)
+		}
+	}
+
+	os.WriteFile(filepath.Join(dir, "de.yml"), []byte("a: b\n"), 0644)
+	if _, err := findLocaleFile(root, "de"); err == nil || !strings.Contains(err.Error(), "both de.yaml and de.yml") {
+		t.Errorf("expected an error for both extensions, got %v", err)
+	}
+
+	paths, err := findTranslationFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var locales []string
+	for _, p := range paths {
+		locales = append(locales, localeName(p))
+	}
+	if want := []string{"de", "de", "fr"}; !reflect.DeepEqual(locales, want) {
+		t.Errorf("findTranslationFiles locales = %v, want %v", locales, want)
+	}
+}
+
+func TestIsLocaleTag(t *testing.T) {
+	for tag, want := range map[string]bool{
+		"de":         true,
+		"en-us":      true,
+		"en-US":      true,
+		"zh-hans":    true,
+		"zh-hans-cn": true,
+		"es-419":     true,
+		"fil":        true,
+		"en_US":      false,
+		"german":     false,
+		"e":          false,
+		"en-":        false,
+		"en-u":       false,
+		"zh-cn-hans": false,
+	} {
+		if got := isLocaleTag(tag); got != want {
+			t.Errorf("isLocaleTag(%q) = %v, want %v", tag, got, want)
+		}
+	}
+}
+
+func TestSourceLocale(t *testing.T) {
+	root := writeCheckFixture(t)
+	transDir := filepath.Join(root, "pkg", "rancher-desktop", "assets", "translations")
+	os.WriteFile(filepath.Join(transDir, "en-gb.yaml"), []byte("nav:\n  home: Home\n  colour: Colour\n"), 0644)
+
+	out, err := captureStdout(t, func() error {
+		return reportMissing(root, "de", "json", missingOptions{sourceLocale: "en-gb"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if want := []string{"nav.colour"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
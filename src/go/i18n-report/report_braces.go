@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func runBraces(args []string) error {
+	fs := flag.NewFlagSet("braces", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportBraces(root, *format)
+}
+
+// braceHit is an en-us.yaml value using "{{name}}" interpolation.
+type braceHit struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// reportBraces lists en-us.yaml values that use Vue's "{{name}}" syntax
+// instead of vue-i18n's "{name}". Such placeholders render literally.
+func reportBraces(root, format string) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+
+	var hits []braceHit
+	for _, k := range sortedKeys(enKeys) {
+		if len(doubleBraceInterpolations(enKeys[k])) > 0 {
+			hits = append(hits, braceHit{Key: k, Value: enKeys[k]})
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No double-brace interpolations found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d values with double-brace interpolation:\n", len(hits))
+	for _, h := range hits {
+		fmt.Printf("  %s\n    %s\n", h.Key, h.Value)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+func runCasing(args []string) error {
+	fs := flag.NewFlagSet("casing", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportCasing(root, *format)
+}
+
+// reportCasing lists en-us.yaml keys that differ only in case,
+// such as "action.Save" and "action.save". They collide in
+// case-insensitive lookups and are usually an accidental near-duplicate.
+func reportCasing(root, format string) error {
+	keys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+
+	groups := findCaseCollisions(keys)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(groups)
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No case collisions found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d case collisions:\n", len(groups))
+	for _, g := range groups {
+		fmt.Printf("  %s\n", strings.Join(g, ", "))
+	}
+	return nil
+}
+
+// findCaseCollisions groups keys by their lowercased form and returns
+// the groups with more than one key, each sorted, ordered by first key.
+func findCaseCollisions(keys map[string]string) [][]string {
+	byLower := make(map[string][]string)
+	for _, k := range sortedKeys(keys) {
+		lower := strings.ToLower(k)
+		byLower[lower] = append(byLower[lower], k)
+	}
+
+	groups := [][]string{}
+	for _, ks := range byLower {
+		if len(ks) > 1 {
+			groups = append(groups, ks)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0] < groups[j][0]
+	})
+	return groups
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindCaseCollisions(t *testing.T) {
+	keys := map[string]string{
+		"action.Save":    "Save",
+		"action.save":    "Save",
+		"action.cancel":  "Cancel",
+		"Nav.Home":       "Home",
+		"nav.home":       "Home",
+		"nav.HOME":       "Home",
+		"nav.homeButton": "Home",
+	}
+	got := findCaseCollisions(keys)
+	want := [][]string{
+		{"Nav.Home", "nav.HOME", "nav.home"},
+		{"action.Save", "action.save"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := findCaseCollisions(map[string]string{"a.b": "x", "a.c": "y"}); len(got) != 0 {
+		t.Errorf("expected no collisions, got %v", got)
+	}
+}
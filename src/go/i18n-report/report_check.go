@@ -1,83 +1,534 @@
 package main
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"flag"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 )
 
 func runCheck(args []string) error {
 	fs := flag.NewFlagSet("check", flag.ExitOnError)
 	locale := fs.String("locale", "", "Target locale code (required)")
+	format := fs.String("format", "text", "Output format: text, junit")
+	lintCalls := fs.Bool("lint-calls", false, "Also fail on t() key literals with leading/trailing whitespace")
+	failOn := fs.String("fail-on", "", "Comma-separated categories that fail the check (default: all): "+strings.Join(checkCategories, ", "))
+	baselinePath := fs.String("baseline", "", "JSON array of known unused/stale keys to ignore")
+	writeBaselinePath := fs.String("write-baseline", "", "Write the current unused/stale keys to this file and exit")
+	github := fs.Bool("github", false, "Also print GitHub Actions ::warning annotations for unused, stale, and missing keys")
+	watch := fs.Bool("watch", false, "Re-run the check whenever translation or source files change, until interrupted")
+	strict := fs.Bool("strict", false, "Also fail on warning-only categories: "+strings.Join(warnOnlyCategories, ", "))
+	keyPattern := keySegmentPatternFlag(fs)
+	strictKeys := fs.Bool("strict-keys", false, "Fail on keys breaking the naming convention without enabling the rest of --strict")
+	exitZero := fs.Bool("exit-zero", false, "Print the full report but exit 0 even when checks fail")
+	fallback := fs.Bool("fallback", false, "Count keys provided by parent locales (de for de-at) as present when checking for missing keys")
+	scanOpts := scanFlags(fs)
+	source := sourceLocaleFlag(fs)
 	fs.Parse(args)
 
 	if *locale == "" {
 		return fmt.Errorf("--locale is required")
 	}
+	if *github && *format == "junit" {
+		return fmt.Errorf("--github cannot be combined with --format=junit")
+	}
+	if *watch && *writeBaselinePath != "" {
+		return fmt.Errorf("--watch cannot be combined with --write-baseline")
+	}
+	scan, err := scanOpts()
+	if err != nil {
+		return err
+	}
+	failSet, err := parseFailOn(*failOn)
+	if err != nil {
+		return err
+	}
+	keySegments, err := keyPattern()
+	if err != nil {
+		return err
+	}
 
 	root, err := repoRoot()
 	if err != nil {
 		return err
 	}
+	opts := checkOptions{
+		lintCalls:    *lintCalls,
+		failOn:       failSet,
+		github:       *github,
+		strict:       *strict,
+		strictKeys:   *strictKeys,
+		exitZero:     *exitZero,
+		fallback:     *fallback,
+		keyNames:     keySegments,
+		sourceLocale: *source,
+		scan:         scan,
+	}
+	if *writeBaselinePath != "" {
+		return writeCheckBaseline(root, *locale, *writeBaselinePath, opts)
+	}
+	if *baselinePath != "" {
+		opts.baseline, err = loadBaseline(*baselinePath)
+		if err != nil {
+			return err
+		}
+	}
+	if *watch {
+		return watchCheck(root, *locale, *format, opts)
+	}
+	return reportCheck(root, *locale, *format, opts)
+}
 
-	enPath := translationsPath(root, "en-us.yaml")
-	localePath := translationsPath(root, *locale+".yaml")
+// checkCategories lists the check result names accepted by --fail-on.
+var checkCategories = []string{"unused", "stale", "missing", "empty", "dynamic", "lint-calls", "locale-names", "casing", "key-names", "whitespace", "icu"}
 
-	enKeys, err := loadYAMLFlat(enPath)
+// warnOnlyCategories only fail the check under --strict or when named in
+// --fail-on.
+var warnOnlyCategories = []string{"dynamic", "locale-names", "casing", "key-names", "whitespace", "icu"}
+
+// parseFailOn parses a comma-separated --fail-on value. An empty value
+// returns nil, meaning every category fails the check.
+func parseFailOn(value string) (map[string]bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	failOn := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, c := range checkCategories {
+			if c == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown --fail-on category %q (valid: %s)", name, strings.Join(checkCategories, ", "))
+		}
+		failOn[name] = true
+	}
+	return failOn, nil
+}
+
+// checkOptions holds the optional behaviors of the check subcommand.
+type checkOptions struct {
+	lintCalls    bool            // also check t() calls for padded keys
+	failOn       map[string]bool // categories that fail the check; nil means all
+	baseline     map[string]bool // known unused/stale keys to ignore
+	github       bool            // print GitHub Actions workflow annotations
+	strict       bool            // let warning-only categories fail
+	strictKeys   bool            // let key-names fail without the rest of strict
+	exitZero     bool            // report failures without returning an error
+	fallback     bool            // resolve missing keys through the parent locale chain
+	keyNames     *regexp.Regexp  // key segment convention; nil means the default
+	sourceLocale string          // locale every category is checked against; empty for en-us
+	scan         scanOptions
+}
+
+// fails reports whether a result fails the check under the given options.
+// Categories excluded by --fail-on are still counted but only warn, as are
+// warning-only categories unless --strict is set or --fail-on names them.
+// --strict-keys makes key-names fail regardless.
+func (r checkResult) fails(opts checkOptions) bool {
+	if len(r.items) == 0 {
+		return false
+	}
+	if r.name == "key-names" && opts.strictKeys {
+		return true
+	}
+	if slices.Contains(warnOnlyCategories, r.name) && !opts.strict {
+		return opts.failOn[r.name]
+	}
+	return opts.failOn == nil || opts.failOn[r.name]
+}
+
+// checkResult is the outcome of one check category.
+type checkResult struct {
+	name  string   // category identifier (e.g. "unused")
+	label string   // description for the text table
+	items []string // offending keys or locations; empty means the check passed
+}
+
+func reportCheck(root, locale, format string, opts checkOptions) error {
+	results, err := runChecks(root, locale, opts)
 	if err != nil {
 		return err
 	}
+	if opts.baseline != nil {
+		fixed := applyBaseline(results, opts.baseline)
+		if len(fixed) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d baseline entries are no longer reported; prune them from the baseline:\n", len(fixed))
+			for _, k := range fixed {
+				fmt.Fprintf(os.Stderr, "  %s\n", k)
+			}
+		}
+	}
+
+	if opts.github {
+		if err := writeGitHubAnnotations(os.Stdout, root, locale, opts.sourceLocale, results); err != nil {
+			return err
+		}
+	}
+
+	passed := true
+	for _, r := range results {
+		if r.fails(opts) {
+			passed = false
+		}
+	}
+
+	switch format {
+	case "junit":
+		if err := writeCheckJUnit(results, locale, opts); err != nil {
+			return err
+		}
+	default:
+		for _, r := range results {
+			status := "OK"
+			if r.fails(opts) {
+				status = "FAIL"
+			} else if len(r.items) > 0 {
+				status = "WARN"
+			}
+			fmt.Printf("  %-30s %3d  %s\n", r.label+":", len(r.items), status)
+		}
+		if passed {
+			fmt.Println("All checks passed.")
+		}
+	}
+
+	if passed || opts.exitZero {
+		return nil
+	}
+	return fmt.Errorf("checks failed")
+}
+
+// runChecks computes every check category for a locale.
+func runChecks(root, locale string, opts checkOptions) ([]checkResult, error) {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return nil, err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	enKeys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return nil, err
+	}
 	localeKeys, err := loadYAMLFlat(localePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	refs, err := findKeyReferences(root, enKeys)
+	refs, dynamics, err := scanKeyReferences(root, enKeys, opts.scan)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Count unused keys.
-	unusedCount := 0
-	for k := range enKeys {
+	// Unused keys.
+	unused := checkResult{name: "unused", label: "unused keys"}
+	for _, k := range sortedKeys(enKeys) {
 		if _, found := refs[k]; !found {
-			unusedCount++
+			unused.items = append(unused.items, k)
 		}
 	}
 
-	// Count stale keys.
-	staleCount := 0
-	for k := range localeKeys {
+	// Stale keys.
+	stale := checkResult{name: "stale", label: "stale keys in " + locale}
+	for _, k := range sortedKeys(localeKeys) {
 		if _, found := enKeys[k]; !found {
-			staleCount++
+			stale.items = append(stale.items, k)
 		}
 	}
 
-	// Count keys missing from locale.
-	missingCount := 0
-	for k := range enKeys {
-		if _, found := localeKeys[k]; !found {
-			missingCount++
+	// Keys missing from locale. With fallback, keys a parent locale
+	// provides are not missing; stale keys are still the file's own.
+	provided := localeKeys
+	missing := checkResult{name: "missing", label: "keys missing from " + locale}
+	if opts.fallback {
+		chain := fallbackChain(locale)
+		provided, err = loadLocaleChain(root, chain)
+		if err != nil {
+			return nil, err
+		}
+		if len(chain) > 1 {
+			missing.label += " (or " + strings.Join(chain[1:], ", ") + ")"
+		}
+	}
+	for _, k := range sortedKeys(enKeys) {
+		if _, found := provided[k]; !found {
+			missing.items = append(missing.items, k)
 		}
 	}
 
-	// Print results.
-	passed := true
-	printResult := func(label string, count int) {
-		status := "OK"
-		if count > 0 {
-			status = "FAIL"
-			passed = false
+	// Keys present in the locale with a blank value.
+	empty := checkResult{name: "empty", label: "empty values in " + locale, items: findEmptyValues(localeKeys, enKeys)}
+
+	// Dynamic patterns that resolve to no key.
+	dynamic := checkResult{name: "dynamic", label: "dynamic patterns with no keys"}
+	for _, d := range unmatchedDynamicPatterns(dynamics, enKeys) {
+		dynamic.items = append(dynamic.items, fmt.Sprintf("%s:%d: %s", d.Ref.File, d.Ref.Line, d.Pattern))
+	}
+
+	// Translation files that vue-i18n would not load as a locale.
+	malformed, err := malformedLocaleFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	localeNames := checkResult{name: "locale-names", label: "malformed locale file names", items: malformed}
+
+	// Keys that collide in case-insensitive lookups.
+	collisions := checkResult{name: "casing", label: "keys differing only in case"}
+	for _, g := range findCaseCollisions(enKeys) {
+		collisions.items = append(collisions.items, strings.Join(g, ", "))
+	}
+
+	// Keys breaking the naming convention.
+	keyNames := checkResult{name: "key-names", label: "keys breaking naming rules"}
+	pattern := opts.keyNames
+	if pattern == nil {
+		pattern = regexp.MustCompile(defaultKeySegmentPattern)
+	}
+	enDefs, err := loadYAMLFlatWithLines(enPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range findKeyNameViolations(enDefs, pattern) {
+		keyNames.items = append(keyNames.items, fmt.Sprintf("%s:%d: %s", filepath.Base(enPath), h.Line, h.Key))
+	}
+
+	// Values with stray whitespace in the checked locale.
+	whitespace := checkResult{name: "whitespace", label: "stray whitespace in " + locale}
+	for _, h := range findWhitespaceIssues(localeKeys, enKeys) {
+		whitespace.items = append(whitespace.items, fmt.Sprintf("%s: %s (%s)", h.Key, h.Visible, strings.Join(h.Issues, ", ")))
+	}
+
+	// ICU messages that would make the formatter throw.
+	icu := checkResult{name: "icu", label: "malformed ICU messages in " + locale}
+	for _, h := range findICUProblems(localeKeys, enKeys) {
+		icu.items = append(icu.items, fmt.Sprintf("%s: %s", h.Key, strings.Join(h.Problems, "; ")))
+	}
+
+	results := []checkResult{unused, stale, missing, empty, dynamic, localeNames, collisions, keyNames, whitespace, icu}
+
+	if opts.lintCalls {
+		warnings, err := lintTranslationCalls(root, opts.scan.exclude)
+		if err != nil {
+			return nil, err
+		}
+		printCallWarnings(warnings)
+		lint := checkResult{name: "lint-calls", label: "t() keys with whitespace"}
+		for _, w := range warnings {
+			lint.items = append(lint.items, fmt.Sprintf("%s:%d: %s", w.Ref.File, w.Ref.Line, w.Message))
 		}
-		fmt.Printf("  %-30s %3d  %s\n", label+":", count, status)
+		results = append(results, lint)
 	}
 
-	printResult("unused keys", unusedCount)
-	printResult("stale keys in "+*locale, staleCount)
-	printResult("keys missing from "+*locale, missingCount)
+	return results, nil
+}
 
-	if passed {
-		fmt.Println("All checks passed.")
-		return nil
+// baselineCategories are the check results a baseline can suppress.
+var baselineCategories = map[string]bool{"unused": true, "stale": true}
+
+// loadBaseline reads a baseline file: a JSON array of keys, the same shape
+// as the JSON output of unused and stale.
+func loadBaseline(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
-	return fmt.Errorf("checks failed")
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+	baseline := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		baseline[k] = true
+	}
+	return baseline, nil
+}
+
+// writeCheckBaseline writes the currently unused and stale keys to path,
+// so a later check with --baseline only fails on new ones.
+func writeCheckBaseline(root, locale, path string, opts checkOptions) error {
+	results, err := runChecks(root, locale, opts)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for _, r := range results {
+		if baselineCategories[r.name] {
+			keys = append(keys, r.items...)
+		}
+	}
+	sort.Strings(keys)
+	if keys == nil {
+		keys = []string{}
+	}
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d keys to baseline %s\n", len(keys), path)
+	return nil
+}
+
+// applyBaseline removes baselined keys from the unused and stale results
+// in place. It returns the sorted baseline keys that were not reported,
+// which have since been fixed and can be pruned from the baseline.
+func applyBaseline(results []checkResult, baseline map[string]bool) []string {
+	reported := make(map[string]bool)
+	for i, r := range results {
+		if !baselineCategories[r.name] {
+			continue
+		}
+		var kept []string
+		for _, item := range r.items {
+			if baseline[item] {
+				reported[item] = true
+				continue
+			}
+			kept = append(kept, item)
+		}
+		results[i].items = kept
+	}
+
+	var fixed []string
+	for k := range baseline {
+		if !reported[k] {
+			fixed = append(fixed, k)
+		}
+	}
+	sort.Strings(fixed)
+	return fixed
+}
+
+// githubLocation formats the file and line parameters of a workflow
+// command. A line of 0 means the line is unknown, and the annotation is
+// left on the file as a whole rather than pointing at line 0.
+func githubLocation(file string, line int) string {
+	if line <= 0 {
+		return "file=" + file
+	}
+	return fmt.Sprintf("file=%s,line=%d", file, line)
+}
+
+// writeGitHubAnnotations prints a GitHub Actions "::warning" workflow
+// command for each unused, stale, and missing key, so the keys show up
+// inline on the pull request. Unused keys point at their line in
+// en-us.yaml and stale keys at their line in the locale file; missing
+// keys have no line to point at and annotate the locale file as a whole.
+func writeGitHubAnnotations(w io.Writer, root, locale, source string, results []checkResult) error {
+	enPath, err := sourceLocalePath(root, source)
+	if err != nil {
+		return err
+	}
+	enFile := filepath.ToSlash(filepath.Join(translationsDir, filepath.Base(enPath)))
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeFile := filepath.ToSlash(filepath.Join(translationsDir, filepath.Base(localePath)))
+
+	enLines, err := loadYAMLKeyLines(enPath)
+	if err != nil {
+		return err
+	}
+	localeLines, err := loadYAMLKeyLines(localePath)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		for _, key := range r.items {
+			switch r.name {
+			case "unused":
+				fmt.Fprintf(w, "::warning %s::%s\n", githubLocation(enFile, enLines[key]), githubEscape(key+" is not referenced in source"))
+			case "stale":
+				fmt.Fprintf(w, "::warning %s::%s\n", githubLocation(localeFile, localeLines[key]), githubEscape(key+" is stale (not in "+filepath.Base(enPath)+")"))
+			case "missing":
+				fmt.Fprintf(w, "::warning %s::%s\n", githubLocation(localeFile, 0), githubEscape(key+" is missing from "+locale))
+			case "locale-names":
+				file := filepath.ToSlash(filepath.Join(translationsDir, key))
+				fmt.Fprintf(w, "::warning file=%s::%s\n", file, githubEscape(key+" is not named after a BCP 47 locale tag"))
+			}
+		}
+	}
+	return nil
+}
+
+// githubEscape escapes the message of a workflow command.
+func githubEscape(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// JUnit XML report structure, limited to the elements CI renderers use.
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeCheckJUnit writes check results to stdout as a JUnit XML test
+// suite with one test case per category. Failing categories carry the
+// count in the failure message and the offending items in its body;
+// categories excluded by --fail-on list their items in system-out instead.
+func writeCheckJUnit(results []checkResult, locale string, opts checkOptions) error {
+	suite := junitTestSuite{
+		Name:  "i18n-report check " + locale,
+		Tests: len(results),
+	}
+	for _, r := range results {
+		tc := junitTestCase{Name: r.name, ClassName: "i18n." + locale}
+		if r.fails(opts) {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d %s", len(r.items), r.label),
+				Text:    strings.Join(r.items, "\n"),
+			}
+		} else if len(r.items) > 0 {
+			tc.SystemOut = strings.Join(r.items, "\n")
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	os.Stdout.WriteString(xml.Header)
+	enc := xml.NewEncoder(os.Stdout)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
 }
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeCheckFixture creates a minimal repository with en-us.yaml, de.yaml
+// and one source file. "nav.about" is unused, "nav.old" is stale in de,
+// and "nav.about" is missing from de.
+func writeCheckFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	srcDir := filepath.Join(dir, "pkg", "rancher-desktop", "pages")
+	os.MkdirAll(transDir, 0755)
+	os.MkdirAll(srcDir, 0755)
+
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("nav:\n  home: Home\n  about: About\n"), 0644)
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte("nav:\n  home: Startseite\n  old: Alt\n"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "Home.vue"), []byte("<h1>{{ t('nav.home') }}</h1>\n"), 0644)
+	return dir
+}
+
+// captureStdout runs fn and returns what it wrote to stdout.
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := fn()
+	w.Close()
+	os.Stdout = oldStdout
+
+	out, _ := io.ReadAll(r)
+	return string(out), err
+}
+
+// captureStderr runs fn and returns what it wrote to stderr.
+func captureStderr(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	err := fn()
+	w.Close()
+	os.Stderr = oldStderr
+
+	out, _ := io.ReadAll(r)
+	return string(out), err
+}
+
+func TestReportCheckJUnit(t *testing.T) {
+	dir := writeCheckFixture(t)
+
+	out, err := captureStdout(t, func() error {
+		return reportCheck(dir, "de", "junit", checkOptions{})
+	})
+	if err == nil {
+		t.Error("expected checks to fail")
+	}
+
+	var suite junitTestSuite
+	if err := xml.Unmarshal([]byte(out), &suite); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, out)
+	}
+	if suite.Tests != 10 || suite.Failures != 3 {
+		t.Errorf("tests=%d failures=%d, want 10 and 3", suite.Tests, suite.Failures)
+	}
+	for _, tc := range suite.Cases {
+		if tc.Name == "dynamic" || tc.Name == "empty" || tc.Name == "locale-names" || tc.Name == "casing" || tc.Name == "key-names" || tc.Name == "whitespace" || tc.Name == "icu" {
+			if tc.Failure != nil {
+				t.Errorf("case %s: unexpected failure %q", tc.Name, tc.Failure.Text)
+			}
+			continue
+		}
+		if tc.Failure == nil {
+			t.Errorf("case %s: expected failure", tc.Name)
+			continue
+		}
+		switch tc.Name {
+		case "unused", "missing":
+			if strings.TrimSpace(tc.Failure.Text) != "nav.about" {
+				t.Errorf("case %s: failure body = %q", tc.Name, tc.Failure.Text)
+			}
+		case "stale":
+			if strings.TrimSpace(tc.Failure.Text) != "nav.old" {
+				t.Errorf("case %s: failure body = %q", tc.Name, tc.Failure.Text)
+			}
+		}
+	}
+}
+
+func TestRunChecksUnmatchedDynamic(t *testing.T) {
+	dir := writeCheckFixture(t)
+	src := "const a = t(`nav.${ page }`);\nconst b = t(`nva.${ page }`);\n"
+	os.WriteFile(filepath.Join(dir, "pkg", "rancher-desktop", "pages", "Nav.vue"), []byte(src), 0644)
+
+	results, err := runChecks(dir, "de", checkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.name != "dynamic" {
+			continue
+		}
+		want := filepath.Join("pkg", "rancher-desktop", "pages", "Nav.vue") + ":2: nva.{}"
+		if len(r.items) != 1 || r.items[0] != want {
+			t.Errorf("dynamic items = %q, want [%q]", r.items, want)
+		}
+		return
+	}
+	t.Error("no dynamic check result")
+}
+
+func TestRunChecksFallback(t *testing.T) {
+	dir := writeCheckFixture(t)
+	os.WriteFile(translationsPath(dir, "de-at.yaml"), []byte("nav:\n  about: Über\n"), 0644)
+
+	for _, fallback := range []bool{false, true} {
+		results, err := runChecks(dir, "de-at", checkOptions{fallback: fallback})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want []string
+		if !fallback {
+			want = []string{"nav.home"}
+		}
+		for _, r := range results {
+			if r.name == "missing" && !reflect.DeepEqual(r.items, want) {
+				t.Errorf("fallback=%v: missing = %q, want %q", fallback, r.items, want)
+			}
+		}
+	}
+}
+
+func TestReportCheckFailOn(t *testing.T) {
+	dir := writeCheckFixture(t)
+
+	// nav.about is unused and missing, so failing only on stale still fails.
+	out, err := captureStdout(t, func() error {
+		return reportCheck(dir, "de", "text", checkOptions{failOn: map[string]bool{"stale": true}})
+	})
+	if err == nil {
+		t.Error("expected stale check to fail")
+	}
+	if !strings.Contains(out, "unused keys:") || !strings.Contains(out, "WARN") {
+		t.Errorf("expected all counts with warnings, got:\n%s", out)
+	}
+
+	os.WriteFile(filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations", "de.yaml"),
+		[]byte("nav:\n  home: Startseite\n"), 0644)
+	_, err = captureStdout(t, func() error {
+		return reportCheck(dir, "de", "text", checkOptions{failOn: map[string]bool{"stale": true}})
+	})
+	if err != nil {
+		t.Errorf("expected unused/missing to only warn, got %v", err)
+	}
+}
+
+func TestReportCheckExitZero(t *testing.T) {
+	dir := writeCheckFixture(t)
+
+	out, err := captureStdout(t, func() error {
+		return reportCheck(dir, "de", "text", checkOptions{exitZero: true})
+	})
+	if err != nil {
+		t.Errorf("expected no error with exitZero, got %v", err)
+	}
+	if !strings.Contains(out, "FAIL") || strings.Contains(out, "All checks passed.") {
+		t.Errorf("expected the failures to be reported, got:\n%s", out)
+	}
+}
+
+func TestParseFailOn(t *testing.T) {
+	got, err := parseFailOn("missing, stale")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !got["missing"] || !got["stale"] {
+		t.Errorf("got %v", got)
+	}
+	if got, _ := parseFailOn(""); got != nil {
+		t.Errorf("empty value: got %v, want nil", got)
+	}
+	if _, err := parseFailOn("missing,typo"); err == nil {
+		t.Error("expected error for unknown category")
+	}
+}
+
+func TestCheckBaseline(t *testing.T) {
+	dir := writeCheckFixture(t)
+	baselinePath := filepath.Join(dir, "baseline.json")
+
+	_, err := captureStdout(t, func() error {
+		return writeCheckBaseline(dir, "de", baselinePath, checkOptions{})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := loadBaseline(baselinePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(baseline) != 2 || !baseline["nav.about"] || !baseline["nav.old"] {
+		t.Fatalf("baseline = %v, want nav.about and nav.old", baseline)
+	}
+
+	// With the baseline, only the missing key still fails.
+	opts := checkOptions{baseline: baseline, failOn: map[string]bool{"unused": true, "stale": true}}
+	if _, err := captureStdout(t, func() error { return reportCheck(dir, "de", "text", opts) }); err != nil {
+		t.Errorf("expected baselined check to pass, got %v", err)
+	}
+
+	// Fixing a baselined key reports it as prunable.
+	results, err := runChecks(dir, "de", checkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline["nav.gone"] = true
+	fixed := applyBaseline(results, baseline)
+	if len(fixed) != 1 || fixed[0] != "nav.gone" {
+		t.Errorf("fixed = %v, want [nav.gone]", fixed)
+	}
+	for _, r := range results {
+		if (r.name == "unused" || r.name == "stale") && len(r.items) != 0 {
+			t.Errorf("%s: items %v not suppressed", r.name, r.items)
+		}
+	}
+}
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	dir := writeCheckFixture(t)
+	results, err := runChecks(dir, "de", checkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	if err := writeGitHubAnnotations(&buf, dir, "de", "", results); err != nil {
+		t.Fatal(err)
+	}
+	want := "::warning file=pkg/rancher-desktop/assets/translations/en-us.yaml,line=3::nav.about is not referenced in source\n" +
+		"::warning file=pkg/rancher-desktop/assets/translations/de.yaml,line=3::nav.old is stale (not in en-us.yaml)\n" +
+		"::warning file=pkg/rancher-desktop/assets/translations/de.yaml::nav.about is missing from de\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	// A key whose line cannot be found annotates the file, not line 0.
+	buf.Reset()
+	unknown := []checkResult{{name: "stale", items: []string{"nav.gone"}}}
+	if err := writeGitHubAnnotations(&buf, dir, "de", "", unknown); err != nil {
+		t.Fatal(err)
+	}
+	want = "::warning file=pkg/rancher-desktop/assets/translations/de.yaml::nav.gone is stale (not in en-us.yaml)\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	// Messages name the --source-locale file.
+	os.WriteFile(translationsPath(dir, "en-gb.yaml"), []byte("nav:\n  home: Home\n"), 0644)
+	buf.Reset()
+	if err := writeGitHubAnnotations(&buf, dir, "de", "en-gb", unknown); err != nil {
+		t.Fatal(err)
+	}
+	want = "::warning file=pkg/rancher-desktop/assets/translations/de.yaml::nav.gone is stale (not in en-gb.yaml)\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRunChecksEmptyValues(t *testing.T) {
+	dir := writeCheckFixture(t)
+	os.WriteFile(filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations", "de.yaml"),
+		[]byte("nav:\n  home: ''\n  about: Über\n"), 0644)
+
+	results, err := runChecks(dir, "de", checkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.name == "empty" {
+			if len(r.items) != 1 || r.items[0] != "nav.home" {
+				t.Errorf("empty items = %v, want [nav.home]", r.items)
+			}
+			if !r.fails(checkOptions{}) {
+				t.Error("empty values should fail the check by default")
+			}
+			return
+		}
+	}
+	t.Error("no empty check result")
+}
+
+func TestRunChecksKeyNames(t *testing.T) {
+	dir := writeCheckFixture(t)
+	os.WriteFile(translationsPath(dir, "en-us.yaml"), []byte("nav:\n  home: Home\n  about: About\n  Help: Help\n"), 0644)
+
+	results, err := runChecks(dir, "de", checkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.name != "key-names" {
+			continue
+		}
+		if len(r.items) != 1 || r.items[0] != "en-us.yaml:4: nav.Help" {
+			t.Errorf("items = %q", r.items)
+		}
+		if r.fails(checkOptions{}) {
+			t.Error("key names should only warn by default")
+		}
+		if !r.fails(checkOptions{strict: true}) {
+			t.Error("key names should fail under --strict")
+		}
+		if !r.fails(checkOptions{strictKeys: true}) {
+			t.Error("key names should fail under --strict-keys")
+		}
+		return
+	}
+	t.Fatal("no key-names result")
+}
+
+func TestRunChecksLocaleNames(t *testing.T) {
+	dir := writeCheckFixture(t)
+	os.WriteFile(filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations", "en_GB.yaml"), []byte("nav:\n  home: Home\n"), 0644)
+
+	results, err := runChecks(dir, "de", checkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.name != "locale-names" {
+			continue
+		}
+		if len(r.items) != 1 || r.items[0] != "en_GB.yaml" {
+			t.Errorf("locale-names items = %v, want [en_GB.yaml]", r.items)
+		}
+		if r.fails(checkOptions{}) {
+			t.Error("malformed locale names should only warn by default")
+		}
+		if !r.fails(checkOptions{strict: true}) {
+			t.Error("malformed locale names should fail under --strict")
+		}
+		return
+	}
+	t.Error("no locale-names check result")
+}
+
+func TestRunChecksICU(t *testing.T) {
+	dir := writeCheckFixture(t)
+	os.WriteFile(translationsPath(dir, "en-us.yaml"), []byte("nav:\n  home: Home\n  items: '{count, plural, one {# item} other {# items}}'\n"), 0644)
+	os.WriteFile(translationsPath(dir, "de.yaml"), []byte("nav:\n  home: Startseite\n  items: '{count, plural, one {# Element}}'\n"), 0644)
+
+	results, err := runChecks(dir, "de", checkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.name != "icu" {
+			continue
+		}
+		want := []string{"nav.items: {count, plural}: missing other"}
+		if !reflect.DeepEqual(r.items, want) {
+			t.Errorf("icu items = %q, want %q", r.items, want)
+		}
+		if r.fails(checkOptions{}) {
+			t.Error("malformed ICU messages should only warn by default")
+		}
+		if !r.fails(checkOptions{strict: true}) {
+			t.Error("malformed ICU messages should fail under --strict")
+		}
+		return
+	}
+	t.Error("no icu check result")
+}
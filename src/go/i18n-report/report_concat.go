@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func runConcat(args []string) error {
+	fs := flag.NewFlagSet("concat", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	exclude := excludeFlag(fs)
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportConcat(root, *format, *exclude)
+}
+
+// concatHit is a source line joining several t() calls with "+".
+type concatHit struct {
+	File    string   `json:"file"`
+	Line    int      `json:"line"`
+	Keys    []string `json:"keys"`
+	Snippet string   `json:"snippet"`
+}
+
+// reportConcat lists source lines that build a sentence from several
+// translated fragments, as in t('msg.prefix') + name + t('msg.suffix').
+// Word order differs between languages, so such a sentence should be one
+// key with an interpolated placeholder. The report is advisory.
+func reportConcat(root, format string, exclude []string) error {
+	files, err := keySourceFiles(root, exclude)
+	if err != nil {
+		return err
+	}
+
+	var hits []concatHit
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		relPath, _ := filepath.Rel(root, file)
+		hits = append(hits, findConcatenatedCalls(relPath, string(data))...)
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No concatenated t() calls found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d lines concatenating t() calls:\n", len(hits))
+	for _, h := range hits {
+		fmt.Printf("  %s:%d: %s\n", h.File, h.Line, h.Snippet)
+	}
+	return nil
+}
+
+// findConcatenatedCalls returns the lines of one file where two keyPattern
+// calls have a "+" between them.
+func findConcatenatedCalls(relPath, src string) []concatHit {
+	var hits []concatHit
+	for i, line := range strings.Split(src, "\n") {
+		matches := keyPattern.FindAllStringSubmatchIndex(line, -1)
+		var keys []string
+		for j := 1; j < len(matches); j++ {
+			between := line[matches[j-1][1]:matches[j][0]]
+			if !strings.Contains(between, "+") {
+				continue
+			}
+			if len(keys) == 0 || keys[len(keys)-1] != line[matches[j-1][2]:matches[j-1][3]] {
+				keys = append(keys, line[matches[j-1][2]:matches[j-1][3]])
+			}
+			keys = append(keys, line[matches[j][2]:matches[j][3]])
+		}
+		if len(keys) > 0 {
+			hits = append(hits, concatHit{File: relPath, Line: i + 1, Keys: keys, Snippet: strings.TrimSpace(line)})
+		}
+	}
+	return hits
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindConcatenatedCalls(t *testing.T) {
+	src := "const a = t('msg.prefix') + name + t('msg.suffix');\n" +
+		"const b = `${ t('one.a') } ${ t('one.b') }`;\n" +
+		"const c = this.t('x.a') + ' ' + this.t('x.b') + $t('x.c');\n" +
+		"const d = t('solo.key') + count;\n"
+
+	got := findConcatenatedCalls("a.ts", src)
+	want := []concatHit{
+		{File: "a.ts", Line: 1, Keys: []string{"msg.prefix", "msg.suffix"}, Snippet: "const a = t('msg.prefix') + name + t('msg.suffix');"},
+		{File: "a.ts", Line: 3, Keys: []string{"x.a", "x.b", "x.c"}, Snippet: "const c = this.t('x.a') + ' ' + this.t('x.b') + $t('x.c');"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+func runDuplicates(args []string) error {
+	fs := flag.NewFlagSet("duplicates", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	minGroup := fs.Int("min", 2, "Minimum number of keys sharing a value to report")
+	minLength := fs.Int("min-length", 2, "Skip values shorter than this many characters")
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportDuplicates(root, *format, *minGroup, *minLength)
+}
+
+// duplicateGroup is an English value shared by several keys.
+type duplicateGroup struct {
+	Value string   `json:"value"`
+	Keys  []string `json:"keys"`
+}
+
+// reportDuplicates lists en-us.yaml values defined under two or more keys.
+// These are candidates for consolidation into a shared key.
+func reportDuplicates(root, format string, minGroup, minLength int) error {
+	enPath := translationsPath(root, "en-us.yaml")
+	keys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return err
+	}
+
+	groups := findDuplicateValues(keys, minGroup, minLength)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(groups)
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No duplicate values found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d duplicate values:\n\n", len(groups))
+	for _, g := range groups {
+		fmt.Printf("  %q (%d keys)\n", g.Value, len(g.Keys))
+		for _, k := range g.Keys {
+			fmt.Printf("    %s\n", k)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// findDuplicateValues groups keys by identical value. Groups with fewer than
+// minGroup keys and values shorter than minLength characters are dropped.
+// Groups are ordered largest first, then by value.
+func findDuplicateValues(keys map[string]string, minGroup, minLength int) []duplicateGroup {
+	byValue := make(map[string][]string)
+	for _, k := range sortedKeys(keys) {
+		v := keys[k]
+		if len([]rune(v)) < minLength {
+			continue
+		}
+		byValue[v] = append(byValue[v], k)
+	}
+
+	var groups []duplicateGroup
+	for v, ks := range byValue {
+		if len(ks) >= minGroup && len(ks) >= 2 {
+			groups = append(groups, duplicateGroup{Value: v, Keys: ks})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Keys) != len(groups[j].Keys) {
+			return len(groups[i].Keys) > len(groups[j].Keys)
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
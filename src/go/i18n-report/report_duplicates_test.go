@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDuplicateValues(t *testing.T) {
+	keys := map[string]string{
+		"generic.cancel":     "Cancel",
+		"dialog.cancel":      "Cancel",
+		"snapshots.cancel":   "Cancel",
+		"generic.ok":         "OK",
+		"dialog.ok":          "OK",
+		"tray.quit":          "Quit",
+		"app.quit":           "Quit",
+		"unit.short":         "s",
+		"unit.seconds":       "s",
+		"preferences.unique": "Unique",
+	}
+
+	got := findDuplicateValues(keys, 2, 2)
+	want := []duplicateGroup{
+		{Value: "Cancel", Keys: []string{"dialog.cancel", "generic.cancel", "snapshots.cancel"}},
+		{Value: "OK", Keys: []string{"dialog.ok", "generic.ok"}},
+		{Value: "Quit", Keys: []string{"app.quit", "tray.quit"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	got = findDuplicateValues(keys, 3, 2)
+	if len(got) != 1 || got[0].Value != "Cancel" {
+		t.Errorf("--min 3: got %+v, want only Cancel", got)
+	}
+
+	got = findDuplicateValues(keys, 2, 3)
+	for _, g := range got {
+		if g.Value == "OK" {
+			t.Errorf("--min-length 3 should skip %q", g.Value)
+		}
+	}
+}
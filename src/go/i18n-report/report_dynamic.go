@@ -5,56 +5,66 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 )
 
 func runDynamic(args []string) error {
 	fs := flag.NewFlagSet("dynamic", flag.ExitOnError)
 	format := fs.String("format", "text", "Output format: text, json")
+	greedy := fs.Bool("greedy-dynamic", false, "Let interpolations match multiple dotted segments")
+	key := fs.String("key", "", "Show only the patterns that match this key, with their sources")
+	locale := fs.String("locale", "", "List, per pattern, the matching keys missing from this locale")
+	source := sourceLocaleFlag(fs)
 	fs.Parse(args)
 
+	if err := checkFormat(*format, "text", "json"); err != nil {
+		return err
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return err
 	}
-	return reportDynamic(root, *format)
+	if *key != "" && *locale != "" {
+		return fmt.Errorf("--key cannot be combined with --locale")
+	}
+	opts := scanOptions{greedyDynamic: *greedy}
+	if *locale != "" {
+		return reportDynamicMissing(root, *source, *locale, *format, opts)
+	}
+	if *key != "" {
+		return reportDynamicKey(root, *key, *format, opts)
+	}
+	return reportDynamic(root, *source, *format, opts)
 }
 
 type dynamicReportEntry struct {
-	Pattern  string   `json:"pattern"`
-	Source   string   `json:"source"`
-	Matches []string `json:"matches"`
+	Pattern    string   `json:"pattern"`
+	Source     string   `json:"source"`
+	Matches    []string `json:"matches"`
+	Suspicious bool     `json:"suspicious,omitempty"`
 }
 
-func reportDynamic(root, format string) error {
-	dynamics, err := findDynamicPatterns(root)
+func reportDynamic(root, source, format string, opts scanOptions) error {
+	dynamics, err := findDynamicPatterns(root, opts)
 	if err != nil {
 		return err
 	}
 
 	// Load en-us.yaml to show which keys each pattern matches.
-	enPath := translationsPath(root, "en-us.yaml")
-	keys, err := loadYAMLFlat(enPath)
+	enPath, err := sourceLocalePath(root, source)
 	if err != nil {
 		return err
 	}
-
-	// Deduplicate patterns (same template from different lines).
-	seen := make(map[string]bool)
-	var unique []dynamicKeyRef
-	for _, d := range dynamics {
-		if !seen[d.Pattern] {
-			seen[d.Pattern] = true
-			unique = append(unique, d)
-		}
+	keys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return err
 	}
-	sort.Slice(unique, func(i, j int) bool {
-		return unique[i].Pattern < unique[j].Pattern
-	})
 
 	// Build report entries.
 	var entries []dynamicReportEntry
-	for _, d := range unique {
+	for _, d := range uniqueDynamicPatterns(dynamics) {
 		var matches []string
 		for _, k := range sortedKeys(keys) {
 			if d.Regex.MatchString(k) {
@@ -62,9 +72,10 @@ func reportDynamic(root, format string) error {
 			}
 		}
 		entries = append(entries, dynamicReportEntry{
-			Pattern: d.Pattern,
-			Source:  fmt.Sprintf("%s:%d", d.Ref.File, d.Ref.Line),
-			Matches: matches,
+			Pattern:    d.Pattern,
+			Source:     fmt.Sprintf("%s:%d", d.Ref.File, d.Ref.Line),
+			Matches:    matches,
+			Suspicious: d.KeyArg && len(matches) == 0,
 		})
 	}
 
@@ -80,6 +91,7 @@ func reportDynamic(root, format string) error {
 	}
 
 	fmt.Printf("Found %d dynamic key patterns:\n\n", len(entries))
+	var suspicious []dynamicReportEntry
 	for _, e := range entries {
 		fmt.Printf("  %s\n", e.Pattern)
 		fmt.Printf("    source:  %s\n", e.Source)
@@ -88,6 +100,203 @@ func reportDynamic(root, format string) error {
 			fmt.Printf("      %s\n", k)
 		}
 		fmt.Println()
+		if e.Suspicious {
+			suspicious = append(suspicious, e)
+		}
+	}
+
+	if len(suspicious) > 0 {
+		fmt.Printf("%d patterns match no keys in %s (check the prefix for typos):\n", len(suspicious), filepath.Base(enPath))
+		for _, e := range suspicious {
+			fmt.Printf("  %s  (%s)\n", e.Pattern, e.Source)
+		}
+	}
+	return nil
+}
+
+// uniqueDynamicPatterns drops repeated patterns (the same template from
+// different lines), keeping the first source, and sorts by pattern.
+func uniqueDynamicPatterns(dynamics []dynamicKeyRef) []dynamicKeyRef {
+	seen := make(map[string]bool)
+	var unique []dynamicKeyRef
+	for _, d := range dynamics {
+		if !seen[d.Pattern] {
+			seen[d.Pattern] = true
+			unique = append(unique, d)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].Pattern < unique[j].Pattern
+	})
+	return unique
+}
+
+// dynamicMissingEntry is a dynamic pattern with the keys it matches that
+// a locale lacks.
+type dynamicMissingEntry struct {
+	Pattern string   `json:"pattern"`
+	Source  string   `json:"source"`
+	Missing []string `json:"missing"`
+}
+
+// reportDynamicMissing lists, for each dynamic pattern, the en-us.yaml
+// keys it matches that the locale does not define. No literal reference
+// names these keys, so this is the translation worklist for keys that
+// are assembled at runtime.
+func reportDynamicMissing(root, source, locale, format string, opts scanOptions) error {
+	dynamics, err := findDynamicPatterns(root, opts)
+	if err != nil {
+		return err
+	}
+	enPath, err := sourceLocalePath(root, source)
+	if err != nil {
+		return err
+	}
+	enKeys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	entries := dynamicMissingKeys(uniqueDynamicPatterns(dynamics), enKeys, localeKeys)
+
+	if format == "json" {
+		if entries == nil {
+			entries = []dynamicMissingEntry{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No dynamic key patterns have keys missing from %s.\n", locale)
+		return nil
+	}
+
+	total := 0
+	for _, e := range entries {
+		total += len(e.Missing)
+	}
+	fmt.Printf("Found %d keys missing from %s in %d dynamic key patterns:\n\n", total, locale, len(entries))
+	for _, e := range entries {
+		fmt.Printf("  %s\n", e.Pattern)
+		fmt.Printf("    source:  %s\n", e.Source)
+		fmt.Printf("    missing: %d keys\n", len(e.Missing))
+		for _, k := range e.Missing {
+			fmt.Printf("      %s\n", k)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// dynamicMissingKeys returns, for each pattern in order, the sorted enKeys
+// it matches that localeKeys lacks. Patterns with nothing missing are
+// left out. A key matched by several patterns is listed under each.
+func dynamicMissingKeys(patterns []dynamicKeyRef, enKeys, localeKeys map[string]string) []dynamicMissingEntry {
+	var entries []dynamicMissingEntry
+	for _, d := range patterns {
+		var missing []string
+		for _, k := range sortedKeys(enKeys) {
+			if _, found := localeKeys[k]; !found && d.Regex.MatchString(k) {
+				missing = append(missing, k)
+			}
+		}
+		if len(missing) > 0 {
+			entries = append(entries, dynamicMissingEntry{
+				Pattern: d.Pattern,
+				Source:  fmt.Sprintf("%s:%d", d.Ref.File, d.Ref.Line),
+				Missing: missing,
+			})
+		}
+	}
+	return entries
+}
+
+// unmatchedDynamicPatterns returns the dynamic patterns that match none of
+// the given keys, sorted by source location. A pattern that resolves to
+// nothing usually means a typo in its static prefix, which would otherwise
+// go unnoticed because no key is reported as used or missing. Only
+// patterns passed directly as a key are considered; other template
+// literals with a dotted prefix (image names, settings paths, selectors)
+// are not keys at all.
+func unmatchedDynamicPatterns(dynamics []dynamicKeyRef, keys map[string]string) []dynamicKeyRef {
+	var unmatched []dynamicKeyRef
+	for _, d := range dynamics {
+		if !d.KeyArg {
+			continue
+		}
+		matched := false
+		for k := range keys {
+			if d.Regex.MatchString(k) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, d)
+		}
+	}
+	sort.SliceStable(unmatched, func(i, j int) bool {
+		if unmatched[i].Ref.File != unmatched[j].Ref.File {
+			return unmatched[i].Ref.File < unmatched[j].Ref.File
+		}
+		return unmatched[i].Ref.Line < unmatched[j].Ref.Line
+	})
+	return unmatched
+}
+
+// reportDynamicKey lists every dynamic pattern occurrence that matches
+// key, answering why a key without literal references counts as used.
+func reportDynamicKey(root, key, format string, opts scanOptions) error {
+	dynamics, err := findDynamicPatterns(root, opts)
+	if err != nil {
+		return err
+	}
+	matches := dynamicPatternsForKey(dynamics, key)
+
+	if format == "json" {
+		entries := make([]dynamicReportEntry, 0, len(matches))
+		for _, d := range matches {
+			entries = append(entries, dynamicReportEntry{
+				Pattern: d.Pattern,
+				Source:  fmt.Sprintf("%s:%d", d.Ref.File, d.Ref.Line),
+				Matches: []string{key},
+			})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No dynamic key patterns match %s.\n", key)
+		return nil
+	}
+
+	fmt.Printf("%s matches %d dynamic key patterns:\n", key, len(matches))
+	for _, d := range matches {
+		fmt.Printf("  %s  (%s:%d)\n", d.Pattern, d.Ref.File, d.Ref.Line)
 	}
 	return nil
 }
+
+// dynamicPatternsForKey returns the dynamic patterns whose regex matches
+// key, in scan order.
+func dynamicPatternsForKey(dynamics []dynamicKeyRef, key string) []dynamicKeyRef {
+	var matches []dynamicKeyRef
+	for _, d := range dynamics {
+		if d.Regex.MatchString(key) {
+			matches = append(matches, d)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestUnmatchedDynamicPatterns(t *testing.T) {
+	keys := map[string]string{
+		"status.running": "Running",
+		"status.stopped": "Stopped",
+	}
+	var dynamics []dynamicKeyRef
+	dynamics = append(dynamics, extractDynamicPatterns("t(`status.${state}`)", keyReference{File: "a.vue", Line: 3}, false)...)
+	dynamics = append(dynamics, extractDynamicPatterns("t(`stauts.${state}`)", keyReference{File: "a.vue", Line: 1}, false)...)
+	// Not passed as a key: image names, settings paths, selectors.
+	dynamics = append(dynamics, extractDynamicPatterns("const image = `docker.io/${ name }`;", keyReference{File: "b.ts", Line: 1}, false)...)
+	dynamics = append(dynamics, extractDynamicPatterns("const key = `lima.${ name }`; t('status.running')", keyReference{File: "b.ts", Line: 2}, false)...)
+
+	got := unmatchedDynamicPatterns(dynamics, keys)
+	if len(got) != 1 {
+		t.Fatalf("got %d unmatched patterns, want 1", len(got))
+	}
+	if got[0].Pattern != "stauts.{}" || got[0].Ref.Line != 1 {
+		t.Errorf("got %s at line %d, want stauts.{} at line 1", got[0].Pattern, got[0].Ref.Line)
+	}
+}
+
+func TestDynamicPatternsForKey(t *testing.T) {
+	var dynamics []dynamicKeyRef
+	dynamics = append(dynamics, extractDynamicPatterns("t(`status.${state}`)", keyReference{File: "a.vue", Line: 1}, false)...)
+	dynamics = append(dynamics, extractDynamicPatterns("t(`nav.${page}`)", keyReference{File: "a.vue", Line: 2}, false)...)
+	dynamics = append(dynamics, extractDynamicPatterns("t(`status.${state}`)", keyReference{File: "b.vue", Line: 7}, false)...)
+
+	got := dynamicPatternsForKey(dynamics, "status.running")
+	if len(got) != 2 {
+		t.Fatalf("got %d patterns, want 2", len(got))
+	}
+	if got[0].Ref.Line != 1 || got[1].Ref.File != "b.vue" {
+		t.Errorf("got %+v", got)
+	}
+	if got := dynamicPatternsForKey(dynamics, "other.key"); len(got) != 0 {
+		t.Errorf("other.key: got %+v, want none", got)
+	}
+}
+
+func TestDynamicMissingKeys(t *testing.T) {
+	enKeys := map[string]string{
+		"status.running": "Running",
+		"status.stopped": "Stopped",
+		"nav.home":       "Home",
+		"nav.about":      "About",
+	}
+	localeKeys := map[string]string{
+		"status.running": "Läuft",
+		"nav.home":       "Start",
+		"nav.about":      "Über",
+	}
+	var dynamics []dynamicKeyRef
+	dynamics = append(dynamics, extractDynamicPatterns("t(`status.${state}`)", keyReference{File: "a.vue", Line: 1}, false)...)
+	dynamics = append(dynamics, extractDynamicPatterns("t(`nav.${page}`)", keyReference{File: "a.vue", Line: 2}, false)...)
+	dynamics = append(dynamics, extractDynamicPatterns("t(`status.${other}`)", keyReference{File: "b.vue", Line: 7}, false)...)
+
+	got := dynamicMissingKeys(uniqueDynamicPatterns(dynamics), enKeys, localeKeys)
+	if len(got) != 1 {
+		t.Fatalf("got %+v, want one pattern", got)
+	}
+	if got[0].Pattern != "status.{}" || got[0].Source != "a.vue:1" || len(got[0].Missing) != 1 || got[0].Missing[0] != "status.stopped" {
+		t.Errorf("got %+v", got[0])
+	}
+}
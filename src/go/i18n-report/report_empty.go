@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func runEmpty(args []string) error {
+	fs := flag.NewFlagSet("empty", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code to check (default: every translation file)")
+	format := fs.String("format", "text", "Output format: text, json")
+	source := sourceLocaleFlag(fs)
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportEmpty(root, *locale, *format, emptyOptions{sourceLocale: *source})
+}
+
+// emptyOptions holds the optional behaviors of the empty subcommand.
+type emptyOptions struct {
+	sourceLocale string // locale whose blank values are deliberate; empty for en-us
+}
+
+// emptyValue is a key whose value is empty or whitespace only.
+type emptyValue struct {
+	File string `json:"file"`
+	Key  string `json:"key"`
+}
+
+// reportEmpty lists keys with empty or whitespace-only values in one
+// locale file, or in every locale file when locale is empty. Such keys
+// exist, so missing does not report them, but they render blank. Keys
+// whose English value is also empty are intentional and not reported.
+func reportEmpty(root, locale, format string, opts emptyOptions) error {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return err
+	}
+	enKeys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	if locale != "" {
+		path, err := findLocaleFile(root, locale)
+		if err != nil {
+			return err
+		}
+		paths = []string{path}
+	} else {
+		paths, err = findTranslationFiles(root)
+		if err != nil {
+			return err
+		}
+	}
+
+	var hits []emptyValue
+	for _, path := range paths {
+		if localeName(path) == sourceLocaleOrDefault(opts.sourceLocale) {
+			continue
+		}
+		keys, err := loadYAMLFlat(path)
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(root, path)
+		for _, k := range findEmptyValues(keys, enKeys) {
+			hits = append(hits, emptyValue{File: relPath, Key: k})
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No empty values found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d empty values:\n", len(hits))
+	for _, h := range hits {
+		fmt.Printf("  %s: %s\n", h.File, h.Key)
+	}
+	return nil
+}
+
+// findEmptyValues returns the sorted keys whose value is empty or
+// consists only of whitespace, skipping keys that are blank in English
+// too (e.g. optional button labels).
+func findEmptyValues(keys, enKeys map[string]string) []string {
+	var empty []string
+	for _, k := range sortedKeys(keys) {
+		if strings.TrimSpace(keys[k]) != "" {
+			continue
+		}
+		if enValue, found := enKeys[k]; found && strings.TrimSpace(enValue) == "" {
+			continue
+		}
+		empty = append(empty, k)
+	}
+	return empty
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindEmptyValues(t *testing.T) {
+	keys := map[string]string{
+		"action.save":   "",
+		"action.cancel": "Abbrechen",
+		"action.reset":  "  \n",
+		"action.icon":   "",
+	}
+	enKeys := map[string]string{
+		"action.save":   "Save",
+		"action.cancel": "Cancel",
+		"action.reset":  "Reset",
+		"action.icon":   "",
+	}
+	got := findEmptyValues(keys, enKeys)
+	want := []string{"action.reset", "action.save"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
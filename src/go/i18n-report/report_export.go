@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code to export (required)")
+	format := fs.String("format", "flat-json", "Output format: flat-json")
+	mergeEN := fs.Bool("merge-en", false, "Fill keys missing from the locale with their English values")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportExport(root, *locale, *format, *mergeEN)
+}
+
+// reportExport writes a locale as a single flat JSON object mapping dotted
+// keys to values, for loaders that consume pre-flattened dictionaries.
+// When mergeEN is true, keys missing from the locale fall back to en-us.yaml
+// so the output is complete.
+func reportExport(root, locale, format string, mergeEN bool) error {
+	if format != "flat-json" {
+		return fmt.Errorf("unsupported export format %q (supported: flat-json)", format)
+	}
+
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	if mergeEN {
+		enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+		if err != nil {
+			return err
+		}
+		for k, v := range enKeys {
+			if _, found := localeKeys[k]; !found {
+				localeKeys[k] = v
+			}
+		}
+	}
+
+	// encoding/json sorts map keys, so the output is stable.
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(localeKeys)
+}
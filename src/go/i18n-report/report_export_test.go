@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportExportMergeEN(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	enUS := `nav:
+  home: Home
+  about: About <b>{appName}</b>
+`
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte(enUS), 0644)
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte("nav:\n  home: Startseite\n"), 0644)
+
+	for _, mergeEN := range []bool{false, true} {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := reportExport(dir, "de", "flat-json", mergeEN)
+		w.Close()
+		os.Stdout = oldStdout
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, _ := io.ReadAll(r)
+		var got map[string]string
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, out)
+		}
+		if got["nav.home"] != "Startseite" {
+			t.Errorf("mergeEN=%v: nav.home = %q", mergeEN, got["nav.home"])
+		}
+		_, hasAbout := got["nav.about"]
+		if hasAbout != mergeEN {
+			t.Errorf("mergeEN=%v: nav.about present = %v", mergeEN, hasAbout)
+		}
+	}
+
+	if err := reportExport(dir, "de", "xml", false); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
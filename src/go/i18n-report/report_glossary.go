@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func runGlossary(args []string) error {
+	fs := flag.NewFlagSet("glossary", flag.ExitOnError)
+	locale := fs.String("locale", "", "Target locale code (required)")
+	glossaryPath := fs.String("glossary", "", "YAML file mapping English terms to approved translations per locale; "+
+		"without it, only English values shared whole by several keys are compared, not terms inside longer strings")
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportGlossary(root, *locale, *glossaryPath, *format)
+}
+
+// glossaryEntry is one translated key involved in a terminology conflict.
+type glossaryEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// glossaryConflict is an English term translated inconsistently. Approved
+// is set when the term comes from a glossary file; otherwise the term is
+// an English value shared by several keys and Entries lists its differing
+// translations.
+type glossaryConflict struct {
+	Term     string          `json:"term"`
+	Approved string          `json:"approved,omitempty"`
+	Entries  []glossaryEntry `json:"entries"`
+}
+
+// reportGlossary flags inconsistent terminology in a locale. Without a
+// glossary file it reports English values shared by several keys that
+// received different translations. With one, it also reports values whose
+// English source contains a glossary term but whose translation lacks the
+// approved rendering.
+func reportGlossary(root, locale, glossaryPath, format string) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	conflicts := findInconsistentTranslations(enKeys, localeKeys)
+	if glossaryPath != "" {
+		glossary, err := loadGlossary(glossaryPath, locale)
+		if err != nil {
+			return err
+		}
+		conflicts = append(conflicts, findGlossaryViolations(glossary, enKeys, localeKeys)...)
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(conflicts)
+	}
+
+	if len(conflicts) == 0 {
+		fmt.Println("No terminology conflicts found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d terminology conflicts in %s:\n\n", len(conflicts), locale)
+	for _, c := range conflicts {
+		if c.Approved != "" {
+			fmt.Printf("  %q (approved: %q)\n", c.Term, c.Approved)
+		} else {
+			fmt.Printf("  %q\n", c.Term)
+		}
+		for _, e := range c.Entries {
+			fmt.Printf("    %s: %s\n", e.Key, e.Value)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// loadGlossary reads a glossary file and returns the approved translation
+// of each English term for locale. Terms without an entry for the locale
+// are omitted. The file maps terms to per-locale translations:
+//
+//	Kubernetes:
+//	  de: Kubernetes
+//	container engine:
+//	  de: Container-Engine
+func loadGlossary(path, locale string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]map[string]string
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	glossary := make(map[string]string)
+	for term, translations := range raw {
+		if approved := translations[locale]; approved != "" {
+			glossary[term] = approved
+		}
+	}
+	return glossary, nil
+}
+
+// findInconsistentTranslations groups keys sharing an English value (as
+// reported by duplicates) and returns the groups whose translated keys do
+// not all use the same translation. Untranslated keys are ignored. Only
+// whole values are compared; terms inside longer strings need a glossary
+// (see findGlossaryViolations).
+func findInconsistentTranslations(enKeys, localeKeys map[string]string) []glossaryConflict {
+	var conflicts []glossaryConflict
+	for _, g := range findDuplicateValues(enKeys, 2, 2) {
+		var entries []glossaryEntry
+		distinct := make(map[string]bool)
+		for _, k := range g.Keys {
+			if v, ok := localeKeys[k]; ok {
+				entries = append(entries, glossaryEntry{Key: k, Value: v})
+				distinct[v] = true
+			}
+		}
+		if len(distinct) > 1 {
+			conflicts = append(conflicts, glossaryConflict{Term: g.Value, Entries: entries})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Term < conflicts[j].Term })
+	return conflicts
+}
+
+// findGlossaryViolations returns, per glossary term, the translated keys
+// whose English value contains the term as a whole word but whose
+// translation does not contain the approved rendering. Both comparisons
+// ignore case.
+func findGlossaryViolations(glossary, enKeys, localeKeys map[string]string) []glossaryConflict {
+	terms := sortedKeys(glossary)
+	var conflicts []glossaryConflict
+	for _, term := range terms {
+		approved := glossary[term]
+		termPattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(term) + `\b`)
+		var entries []glossaryEntry
+		for _, k := range sortedKeys(localeKeys) {
+			en, ok := enKeys[k]
+			if !ok || !termPattern.MatchString(en) {
+				continue
+			}
+			v := localeKeys[k]
+			if !strings.Contains(strings.ToLower(v), strings.ToLower(approved)) {
+				entries = append(entries, glossaryEntry{Key: k, Value: v})
+			}
+		}
+		if len(entries) > 0 {
+			conflicts = append(conflicts, glossaryConflict{Term: term, Approved: approved, Entries: entries})
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindInconsistentTranslations(t *testing.T) {
+	enKeys := map[string]string{
+		"generic.cancel": "Cancel",
+		"dialog.cancel":  "Cancel",
+		"tray.cancel":    "Cancel",
+		"generic.ok":     "OK",
+		"dialog.ok":      "OK",
+	}
+	localeKeys := map[string]string{
+		"generic.cancel": "Abbrechen",
+		"dialog.cancel":  "Stornieren",
+		"generic.ok":     "OK",
+		"dialog.ok":      "OK",
+	}
+
+	got := findInconsistentTranslations(enKeys, localeKeys)
+	want := []glossaryConflict{
+		{Term: "Cancel", Entries: []glossaryEntry{
+			{Key: "dialog.cancel", Value: "Stornieren"},
+			{Key: "generic.cancel", Value: "Abbrechen"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestFindInconsistentTranslationsWholeValues(t *testing.T) {
+	// "Kubernetes" is translated two ways, but only inside longer values,
+	// which are not compared without a glossary.
+	enKeys := map[string]string{
+		"k8s.enable":  "Enable Kubernetes",
+		"k8s.version": "Kubernetes version",
+	}
+	localeKeys := map[string]string{
+		"k8s.enable":  "K8s aktivieren",
+		"k8s.version": "Kubernetes-Version",
+	}
+	if got := findInconsistentTranslations(enKeys, localeKeys); len(got) != 0 {
+		t.Errorf("got %+v, want no conflicts", got)
+	}
+}
+
+func TestFindGlossaryViolations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "glossary.yaml")
+	data := "container engine:\n  de: Container-Engine\n  fa: موتور کانتینر\nKubernetes:\n  fa: Kubernetes\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	glossary, err := loadGlossary(path, "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"container engine": "Container-Engine"}; !reflect.DeepEqual(glossary, want) {
+		t.Fatalf("loadGlossary = %v, want %v", glossary, want)
+	}
+
+	enKeys := map[string]string{
+		"engine.title":   "Container Engine",
+		"engine.help":    "Choose the container engine to use",
+		"engine.plural":  "Container engines",
+		"engine.unknown": "Unrelated",
+	}
+	localeKeys := map[string]string{
+		"engine.title":   "Container-Engine",
+		"engine.help":    "Wählen Sie die Container-Laufzeit",
+		"engine.plural":  "Container-Laufzeiten",
+		"engine.unknown": "Unabhängig",
+	}
+
+	got := findGlossaryViolations(glossary, enKeys, localeKeys)
+	want := []glossaryConflict{
+		{Term: "container engine", Approved: "Container-Engine", Entries: []glossaryEntry{
+			{Key: "engine.help", Value: "Wählen Sie die Container-Laufzeit"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
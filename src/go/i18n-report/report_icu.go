@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func runICU(args []string) error {
+	fs := flag.NewFlagSet("icu", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code to check (default: every translation file, including en-us)")
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	if err := checkFormat(*format, "text", "json"); err != nil {
+		return err
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportICU(root, *locale, *format)
+}
+
+// icuProblem is a value with malformed ICU MessageFormat syntax, or with
+// ICU argument names that differ from the English value.
+type icuProblem struct {
+	File     string   `json:"file"`
+	Key      string   `json:"key"`
+	Problems []string `json:"problems"`
+}
+
+// reportICU validates the ICU MessageFormat values in one locale file, or
+// in every translation file when locale is empty. A malformed message
+// makes the formatter throw at runtime instead of rendering the key.
+func reportICU(root, locale, format string) error {
+	enPath := translationsPath(root, "en-us.yaml")
+	enKeys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	if locale != "" {
+		path, err := findLocaleFile(root, locale)
+		if err != nil {
+			return err
+		}
+		paths = []string{path}
+	} else {
+		paths, err = findTranslationFiles(root)
+		if err != nil {
+			return err
+		}
+	}
+
+	var hits []icuProblem
+	for _, path := range paths {
+		keys, err := loadYAMLFlat(path)
+		if err != nil {
+			return err
+		}
+		reference := enKeys
+		if path == enPath {
+			reference = nil
+		}
+		relPath, _ := filepath.Rel(root, path)
+		for _, h := range findICUProblems(keys, reference) {
+			h.File = relPath
+			hits = append(hits, h)
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No ICU message problems found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d values with ICU message problems:\n", len(hits))
+	for _, h := range hits {
+		fmt.Printf("  %s: %s: %s\n", h.File, h.Key, strings.Join(h.Problems, "; "))
+	}
+	return nil
+}
+
+// findICUProblems validates every value using ICU argument syntax,
+// sorted by key. When enKeys is non-nil, the argument names of each value
+// are also compared with its English value wherever either side uses ICU.
+func findICUProblems(keys, enKeys map[string]string) []icuProblem {
+	var hits []icuProblem
+	for _, k := range sortedKeys(keys) {
+		value := keys[k]
+		var problems []string
+		if isICUMessage(value) {
+			problems = icuErrors(value)
+		}
+		if enValue, found := enKeys[k]; found && (isICUMessage(value) || isICUMessage(enValue)) {
+			missing, extra := placeholderDiff(enValue, value)
+			for _, n := range missing {
+				problems = append(problems, fmt.Sprintf("argument {%s} missing", n))
+			}
+			for _, n := range extra {
+				problems = append(problems, fmt.Sprintf("argument {%s} not in en-us", n))
+			}
+		}
+		if len(problems) > 0 {
+			hits = append(hits, icuProblem{Key: k, Problems: problems})
+		}
+	}
+	return hits
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindICUProblems(t *testing.T) {
+	enKeys := map[string]string{
+		"images.delete": "Delete {count, plural, one {# image} other {# images}}?",
+		"images.pull":   "Pulling {name}",
+		"nav.home":      "Home",
+	}
+	keys := map[string]string{
+		"images.delete": "{anzahl, plural, one {# Bild} other {# Bilder}} löschen?",
+		"images.pull":   "{name} wird geladen",
+		"nav.home":      "Startseite {",
+	}
+	got := findICUProblems(keys, enKeys)
+	want := []icuProblem{{
+		Key:      "images.delete",
+		Problems: []string{"argument {count} missing", "argument {anzahl} not in en-us"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// defaultKeySegmentPattern is the naming convention for key segments:
+// camelCase starting with a lowercase letter.
+const defaultKeySegmentPattern = `^[a-z][a-zA-Z0-9]*$`
+
+// sequenceIndexPattern matches the index segments of flattened YAML
+// sequences ("tips.0"), which no naming convention applies to.
+var sequenceIndexPattern = regexp.MustCompile(`^\d+$`)
+
+// keySegmentPatternFlag registers --key-pattern on fs and returns a
+// function that compiles its value after fs.Parse.
+func keySegmentPatternFlag(fs *flag.FlagSet) func() (*regexp.Regexp, error) {
+	pattern := fs.String("key-pattern", defaultKeySegmentPattern, "Regular expression every key segment must match")
+	return func() (*regexp.Regexp, error) {
+		re, err := regexp.Compile(*pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --key-pattern: %w", err)
+		}
+		return re, nil
+	}
+}
+
+func runKeyNames(args []string) error {
+	fs := flag.NewFlagSet("key-names", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	keyPattern := keySegmentPatternFlag(fs)
+	source := sourceLocaleFlag(fs)
+	fs.Parse(args)
+
+	if err := checkFormat(*format, "text", "json"); err != nil {
+		return err
+	}
+	pattern, err := keyPattern()
+	if err != nil {
+		return err
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportKeyNames(root, *source, *format, pattern)
+}
+
+// keyNameHit is an en-us.yaml key with segments breaking the naming
+// convention.
+type keyNameHit struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Key      string   `json:"key"`
+	Segments []string `json:"segments"` // the offending segments
+}
+
+// reportKeyNames lists en-us.yaml keys with a segment that does not match
+// pattern, with the line that defines each.
+func reportKeyNames(root, source, format string, pattern *regexp.Regexp) error {
+	enPath, err := sourceLocalePath(root, source)
+	if err != nil {
+		return err
+	}
+	defs, err := loadYAMLFlatWithLines(enPath)
+	if err != nil {
+		return err
+	}
+	hits := findKeyNameViolations(defs, pattern)
+	relPath, _ := filepath.Rel(root, enPath)
+	for i := range hits {
+		hits[i].File = relPath
+	}
+
+	if format == "json" {
+		if hits == nil {
+			hits = []keyNameHit{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No keys breaking the naming convention found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d keys breaking the naming convention (%s):\n", len(hits), pattern)
+	for _, h := range hits {
+		fmt.Printf("  %s:%d: %s (%s)\n", h.File, h.Line, h.Key, strings.Join(h.Segments, ", "))
+	}
+	return nil
+}
+
+// findKeyNameViolations returns, sorted by key, the keys in defs with at
+// least one segment that pattern does not match. Sequence indexes are
+// not checked.
+func findKeyNameViolations(defs map[string]keyDefinition, pattern *regexp.Regexp) []keyNameHit {
+	var hits []keyNameHit
+	for _, k := range slices.Sorted(maps.Keys(defs)) {
+		var bad []string
+		for _, seg := range strings.Split(k, ".") {
+			if !sequenceIndexPattern.MatchString(seg) && !pattern.MatchString(seg) {
+				bad = append(bad, seg)
+			}
+		}
+		if len(bad) > 0 {
+			hits = append(hits, keyNameHit{Line: defs[k].Line, Key: k, Segments: bad})
+		}
+	}
+	return hits
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFindKeyNameViolations(t *testing.T) {
+	defs := map[string]keyDefinition{
+		"nav.home":              {Line: 2},
+		"Nav.about":             {Line: 3},
+		"prefs.onlyWithVZ_x64":  {Line: 5},
+		"locale.en-us":          {Line: 7},
+		"tips.0":                {Line: 9},
+		"options.9p.label":      {Line: 11},
+		"diagnostics.ErrorCode": {Line: 13},
+	}
+
+	got := findKeyNameViolations(defs, regexp.MustCompile(defaultKeySegmentPattern))
+	want := []keyNameHit{
+		{Line: 3, Key: "Nav.about", Segments: []string{"Nav"}},
+		{Line: 13, Key: "diagnostics.ErrorCode", Segments: []string{"ErrorCode"}},
+		{Line: 7, Key: "locale.en-us", Segments: []string{"en-us"}},
+		{Line: 11, Key: "options.9p.label", Segments: []string{"9p"}},
+		{Line: 5, Key: "prefs.onlyWithVZ_x64", Segments: []string{"onlyWithVZ_x64"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	// A looser convention accepts hyphens and underscores.
+	got = findKeyNameViolations(defs, regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`))
+	if len(got) != 0 {
+		t.Errorf("custom pattern: got %+v, want none", got)
+	}
+}
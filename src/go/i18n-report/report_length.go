@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"unicode/utf8"
+)
+
+// minLengthSource is the shortest English value (in runes) compared by
+// the length report. Short labels like "OK" grow several times over in
+// most languages without causing layout problems.
+const minLengthSource = 10
+
+func runLength(args []string) error {
+	fs := flag.NewFlagSet("length", flag.ExitOnError)
+	locale := fs.String("locale", "", "Target locale code (required)")
+	format := fs.String("format", "text", "Output format: text, json")
+	ratio := fs.Float64("ratio", 2.0, "Report translations longer than this multiple of the English length")
+	fail := fs.Bool("fail", false, "Exit non-zero when any translation exceeds the ratio")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+	if *ratio <= 0 {
+		return fmt.Errorf("--ratio must be positive")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportLength(root, *locale, *ratio, *format, *fail)
+}
+
+// lengthHit is a translation much longer than its English source.
+type lengthHit struct {
+	Key          string  `json:"key"`
+	EnglishRunes int     `json:"englishRunes"`
+	LocaleRunes  int     `json:"localeRunes"`
+	Ratio        float64 `json:"ratio"`
+}
+
+// reportLength lists translations longer than ratio times their English
+// value, measured in runes, as candidates for overflowing buttons and
+// menus. The report is advisory unless fail is set.
+func reportLength(root, locale string, ratio float64, format string, fail bool) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	hits := findLongTranslations(enKeys, localeKeys, ratio)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(hits); err != nil {
+			return err
+		}
+	} else if len(hits) == 0 {
+		fmt.Printf("No translations in %s exceed %.1fx the English length.\n", locale, ratio)
+	} else {
+		fmt.Printf("Found %d translations in %s longer than %.1fx the English length:\n", len(hits), locale, ratio)
+		for _, h := range hits {
+			fmt.Printf("  %s: %d -> %d runes (%.1fx)\n", h.Key, h.EnglishRunes, h.LocaleRunes, h.Ratio)
+		}
+	}
+
+	if fail && len(hits) > 0 {
+		return fmt.Errorf("%d translations exceed the length ratio", len(hits))
+	}
+	return nil
+}
+
+// findLongTranslations returns the translated keys whose rune length
+// exceeds ratio times the English length. English values shorter than
+// minLengthSource are skipped.
+func findLongTranslations(enKeys, localeKeys map[string]string, ratio float64) []lengthHit {
+	var hits []lengthHit
+	for _, k := range sortedKeys(enKeys) {
+		value, found := localeKeys[k]
+		if !found {
+			continue
+		}
+		enLen := utf8.RuneCountInString(enKeys[k])
+		if enLen < minLengthSource {
+			continue
+		}
+		localeLen := utf8.RuneCountInString(value)
+		r := float64(localeLen) / float64(enLen)
+		if r > ratio {
+			hits = append(hits, lengthHit{Key: k, EnglishRunes: enLen, LocaleRunes: localeLen, Ratio: r})
+		}
+	}
+	return hits
+}
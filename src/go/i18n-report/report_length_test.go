@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindLongTranslations(t *testing.T) {
+	enKeys := map[string]string{
+		"action.ok":      "OK",
+		"action.restart": "Restart now",
+		"action.reset":   "Reset Kubernetes",
+	}
+	localeKeys := map[string]string{
+		"action.ok":      "Einverstanden und weiter",
+		"action.restart": "Jetzt sofort und ohne weitere Rückfrage neu starten",
+		"action.reset":   "Kubernetes zurücksetzen",
+	}
+
+	got := findLongTranslations(enKeys, localeKeys, 2.0)
+	if len(got) != 1 || got[0].Key != "action.restart" {
+		t.Fatalf("got %+v, want only action.restart", got)
+	}
+	if got[0].EnglishRunes != 11 || got[0].LocaleRunes != 51 {
+		t.Errorf("runes = %d -> %d, want 11 -> 51", got[0].EnglishRunes, got[0].LocaleRunes)
+	}
+}
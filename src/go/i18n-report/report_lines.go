@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func runLines(args []string) error {
+	fs := flag.NewFlagSet("lines", flag.ExitOnError)
+	locale := fs.String("locale", "", "Target locale code (required)")
+	format := fs.String("format", "text", "Output format: text, json")
+	tolerance := fs.Int("tolerance", 0, "Allowed difference in line count before a key is reported")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportLines(root, *locale, *format, *tolerance)
+}
+
+// lineMismatch records a multiline value whose translation has a
+// different number of lines.
+type lineMismatch struct {
+	Key          string `json:"key"`
+	EnglishLines int    `json:"englishLines"`
+	LocaleLines  int    `json:"localeLines"`
+}
+
+// reportLines compares the line count of multiline en-us.yaml values with
+// their translations. A translation that collapses a bullet list or
+// paragraph breaks into fewer lines may render incorrectly.
+func reportLines(root, locale, format string, tolerance int) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	mismatches := findLineMismatches(enKeys, localeKeys, tolerance)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(mismatches)
+	}
+
+	if len(mismatches) == 0 {
+		fmt.Printf("No line count mismatches found in %s.\n", locale)
+		return nil
+	}
+
+	fmt.Printf("Found %d line count mismatches in %s:\n", len(mismatches), locale)
+	for _, m := range mismatches {
+		fmt.Printf("  %s: %d lines in English, %d in %s\n", m.Key, m.EnglishLines, m.LocaleLines, locale)
+	}
+	return nil
+}
+
+// findLineMismatches returns the multiline English keys whose translated
+// line count differs by more than tolerance. Untranslated keys are skipped.
+func findLineMismatches(enKeys, localeKeys map[string]string, tolerance int) []lineMismatch {
+	var mismatches []lineMismatch
+	for _, k := range sortedKeys(enKeys) {
+		enLines := countLines(enKeys[k])
+		if enLines < 2 {
+			continue
+		}
+		value, found := localeKeys[k]
+		if !found {
+			continue
+		}
+		localeLines := countLines(value)
+		diff := enLines - localeLines
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			mismatches = append(mismatches, lineMismatch{Key: k, EnglishLines: enLines, LocaleLines: localeLines})
+		}
+	}
+	return mismatches
+}
+
+// countLines returns the number of lines in a value, ignoring the trailing
+// newline that YAML block scalars add.
+func countLines(s string) int {
+	return strings.Count(strings.TrimRight(s, "\n"), "\n") + 1
+}
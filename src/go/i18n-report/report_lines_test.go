@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindLineMismatches(t *testing.T) {
+	enKeys := map[string]string{
+		"help.steps":  "First step\nSecond step\nThird step\n",
+		"help.pair":   "One\nTwo",
+		"help.single": "Just one line",
+		"help.todo":   "Not\ntranslated",
+	}
+	localeKeys := map[string]string{
+		"help.steps":  "Erster Schritt\nZweiter Schritt\n",
+		"help.pair":   "Eins\nZwei\n",
+		"help.single": "Nur eine\nZeile",
+	}
+
+	got := findLineMismatches(enKeys, localeKeys, 0)
+	want := []lineMismatch{{Key: "help.steps", EnglishLines: 3, LocaleLines: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if got := findLineMismatches(enKeys, localeKeys, 1); len(got) != 0 {
+		t.Errorf("tolerance 1: got %+v, want none", got)
+	}
+}
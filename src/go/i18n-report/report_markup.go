@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// htmlTagPattern matches an opening, closing, or self-closing HTML tag and
+// captures the slash, tag name, and self-closing slash.
+var htmlTagPattern = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9-]*)(?:\s[^<>]*?)?(/?)>`)
+
+func runMarkup(args []string) error {
+	fs := flag.NewFlagSet("markup", flag.ExitOnError)
+	locale := fs.String("locale", "", "Target locale code (required)")
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportMarkup(root, *locale, *format)
+}
+
+// markupMismatch records a key whose translation has different HTML tags
+// than the English value.
+type markupMismatch struct {
+	Key        string   `json:"key"`
+	English    []string `json:"english"`
+	Translated []string `json:"translated"`
+}
+
+// reportMarkup compares the HTML tags in each en-us.yaml value with its
+// translation and reports keys where the tags were dropped or mangled.
+func reportMarkup(root, locale, format string) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	mismatches := findMarkupMismatches(enKeys, localeKeys)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(mismatches)
+	}
+
+	if len(mismatches) == 0 {
+		fmt.Printf("No markup mismatches found in %s.\n", locale)
+		return nil
+	}
+
+	fmt.Printf("Found %d markup mismatches in %s:\n", len(mismatches), locale)
+	for _, m := range mismatches {
+		fmt.Printf("  %s\n    en-us: %s\n    %s: %s\n", m.Key, strings.Join(m.English, " "), locale, strings.Join(m.Translated, " "))
+	}
+	return nil
+}
+
+// findMarkupMismatches returns the translated keys whose multiset of HTML
+// tags differs from the English value. Keys missing from the locale are
+// skipped.
+func findMarkupMismatches(enKeys, localeKeys map[string]string) []markupMismatch {
+	var mismatches []markupMismatch
+	for _, k := range sortedKeys(enKeys) {
+		value, found := localeKeys[k]
+		if !found {
+			continue
+		}
+		enTags := htmlTags(enKeys[k])
+		localeTags := htmlTags(value)
+		if !sameTags(enTags, localeTags) {
+			mismatches = append(mismatches, markupMismatch{Key: k, English: enTags, Translated: localeTags})
+		}
+	}
+	return mismatches
+}
+
+// htmlTags returns the HTML tags in s, normalized to "<name>", "</name>",
+// or "<name/>" with attributes dropped, in order of appearance.
+func htmlTags(s string) []string {
+	var tags []string
+	for _, m := range htmlTagPattern.FindAllStringSubmatch(s, -1) {
+		name := strings.ToLower(m[2])
+		switch {
+		case m[1] == "/":
+			tags = append(tags, "</"+name+">")
+		case m[3] == "/":
+			tags = append(tags, "<"+name+"/>")
+		default:
+			tags = append(tags, "<"+name+">")
+		}
+	}
+	return tags
+}
+
+// sameTags reports whether a and b contain the same tags, ignoring order,
+// since translations may legitimately move markup within a sentence.
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string(nil), a...)
+	sb := append([]string(nil), b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHTMLTags(t *testing.T) {
+	got := htmlTags(`See <a href='https://docs.rancherdesktop.io'>the <b>docs</b></a>.<br/>`)
+	want := []string{"<a>", "<b>", "</b>", "</a>", "<br/>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := htmlTags("Use {count} < 5 items"); got != nil {
+		t.Errorf("plain text: got %v, want none", got)
+	}
+}
+
+func TestFindMarkupMismatches(t *testing.T) {
+	enKeys := map[string]string{
+		"help.docs":  "See <a href='https://example.com'>docs</a>",
+		"help.bold":  "<b>Note:</b> restart required",
+		"help.moved": "Click <b>Save</b> to continue",
+		"help.plain": "Plain text",
+		"help.todo":  "See <a href='x'>this</a>",
+	}
+	localeKeys := map[string]string{
+		"help.docs":  "Siehe <a href='https://example.de'>Doku",
+		"help.bold":  "<b>Hinweis:</b> Neustart erforderlich",
+		"help.moved": "Zum Fortfahren <b>Speichern</b> klicken",
+		"help.plain": "Text",
+	}
+
+	got := findMarkupMismatches(enKeys, localeKeys)
+	want := []markupMismatch{{
+		Key:        "help.docs",
+		English:    []string{"<a>", "</a>"},
+		Translated: []string{"<a>"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
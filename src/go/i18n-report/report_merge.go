@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"unicode"
 )
 
 // mergeEntry holds a translated key-value pair with an optional @reason comment.
@@ -15,30 +17,145 @@ type mergeEntry struct {
 	key     string
 	value   string
 	comment string // may be multi-line (joined with "\n")
+	tag     string // YAML tag of an unquoted non-string scalar (e.g. "!!int"), if any
+}
+
+// mergeOptions holds the optional behaviors of the merge subcommand.
+type mergeOptions struct {
+	matchSource     bool     // write keys in en-us.yaml order instead of alphabetically
+	validate        bool     // check the written file against en-us.yaml
+	blankDepth      int      // nesting levels separated by blank lines
+	wrap            int      // fold string values longer than this; 0 disables
+	dryRun          bool     // report what would change without writing
+	commentPrefixes []string // input annotations to keep; nil means defaultCommentPrefixes
+	exact           bool     // overwrite values that differ only in trailing whitespace
+	backup          bool     // copy the locale file to <file>.bak before rewriting it
+	noOverwrite     bool     // keep existing non-empty values instead of replacing them
+	reportConflicts bool     // list incoming values that differ from existing ones
+	quiet           bool     // omit the summary lines on stderr
+	validateSource  bool     // report incoming keys absent from en-us.yaml
+	strict          bool     // with validateSource, refuse to merge such keys
+	dropIdentical   bool     // skip incoming values equal to the en-us.yaml value
+	rtlLocales      []string // locales checked for positional reordering; nil means defaultRTLLocales
+}
+
+// expandInputDirs replaces each directory in paths with the regular
+// files directly inside it, sorted by name so batches merge in a
+// deterministic order. Hidden files and subdirectories are skipped.
+func expandInputDirs(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			// Missing files are reported when read.
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path) // sorted by file name
+		if err != nil {
+			return nil, err
+		}
+		n := len(files)
+		for _, e := range entries {
+			if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") {
+				files = append(files, filepath.Join(path, e.Name()))
+			}
+		}
+		if len(files) == n {
+			return nil, fmt.Errorf("no input files in %s", path)
+		}
+	}
+	return files, nil
+}
+
+// defaultCommentPrefixes are the merge input annotations preserved when
+// --comment-prefix is not given: every "# @" annotation, so @context
+// notes echoed back from translate output and @no-translate markers
+// survive into the locale file alongside @reason.
+var defaultCommentPrefixes = []string{"@"}
+
+// parseCommentPrefixes splits a comma-separated --comment-prefix value.
+// A leading "#" on each prefix is optional.
+func parseCommentPrefixes(value string) []string {
+	var prefixes []string
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(p), "#"))
+		if p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return prefixes
 }
 
 func runMerge(args []string) error {
 	fs := flag.NewFlagSet("merge", flag.ExitOnError)
 	locale := fs.String("locale", "", "Target locale code (required)")
+	matchSource := fs.Bool("match-source", false, "Order keys to follow en-us.yaml instead of sorting alphabetically")
+	validate := fs.Bool("validate", false, "Validate the written file (placeholders, stale keys, unquoted non-strings) and fail on problems")
+	blankDepth := fs.Int("blank-depth", 1, "Separate groups with blank lines down to this nesting level (1 = top-level only)")
+	wrap := fs.Int("wrap", 0, "Write values longer than this many characters as folded block scalars (0 = never)")
+	dryRun := fs.Bool("dry-run", false, "Report how many keys would be added without writing the locale file")
+	exact := fs.Bool("exact", false, "Overwrite existing values that differ from the input only in trailing whitespace")
+	backup := fs.Bool("backup", false, "Copy the locale file to <file>.bak before rewriting it, replacing any earlier backup")
+	noOverwrite := fs.Bool("no-overwrite", false, "Keep existing non-empty values; ignore incoming values for those keys")
+	reportConflicts := fs.Bool("report-conflicts", false, "List keys whose incoming value differs from the existing translation on stderr")
+	quiet := fs.Bool("quiet", false, "Do not print summary lines (keys merged, kept, skipped, validated) on stderr")
+	validateSource := fs.Bool("validate-against-source", false, "Warn about incoming keys not defined in en-us.yaml before writing")
+	strict := fs.Bool("strict", false, "With --validate-against-source, reject the merge instead of warning")
+	dropIdentical := fs.Bool("drop-identical", false, "Skip incoming values identical to the en-us.yaml value, and remove such existing entries")
+	dir := fs.String("dir", "", "Also read every file in this directory, in file name order (e.g. one agent output per batch)")
+	rtlLocales := fs.String("rtl-locales", strings.Join(defaultRTLLocales, ","), "Comma-separated right-to-left locales whose validation warns about reordered positional placeholders")
+	commentPrefix := fs.String("comment-prefix", strings.Join(defaultCommentPrefixes, ","), "Comma-separated comment annotations to keep from the input (e.g. NOTE:)")
 	fs.Parse(args)
 
 	if *locale == "" {
 		return fmt.Errorf("--locale is required")
 	}
+	if *wrap < 0 {
+		return fmt.Errorf("--wrap must not be negative")
+	}
+	if *strict && !*validateSource {
+		return fmt.Errorf("--strict requires --validate-against-source")
+	}
 
 	root, err := repoRoot()
 	if err != nil {
 		return err
 	}
-	return reportMerge(root, *locale, fs.Args())
+	files := fs.Args()
+	if *dir != "" {
+		files = append(files, *dir)
+	}
+	return reportMerge(root, *locale, files, mergeOptions{
+		matchSource:     *matchSource,
+		validate:        *validate,
+		blankDepth:      *blankDepth,
+		wrap:            *wrap,
+		dryRun:          *dryRun,
+		commentPrefixes: parseCommentPrefixes(*commentPrefix),
+		exact:           *exact,
+		backup:          *backup,
+		noOverwrite:     *noOverwrite,
+		reportConflicts: *reportConflicts,
+		quiet:           *quiet,
+		validateSource:  *validateSource,
+		strict:          *strict,
+		dropIdentical:   *dropIdentical,
+		rtlLocales:      parseRTLLocales(*rtlLocales),
+	})
 }
 
 // reportMerge reads flat key=value pairs with @reason comments and writes
 // (or updates) a nested YAML locale file. Input sources:
-//   - File arguments: agent output (JSONL), markdown, or raw flat text
+//   - File arguments: agent output (JSONL), markdown, or raw flat text;
+//     a directory argument stands for the files in it (see expandInputDirs)
 //   - Stdin (when no files given): raw flat text
-func reportMerge(root, locale string, files []string) error {
-	localePath := translationsPath(root, locale+".yaml")
+func reportMerge(root, locale string, files []string, opts mergeOptions) error {
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	enPath := translationsPath(root, "en-us.yaml")
 
 	// Read existing locale entries, preserving comments.
 	existing := make(map[string]mergeEntry)
@@ -52,12 +169,21 @@ func reportMerge(root, locale string, files []string) error {
 	// Build input reader from file arguments or stdin.
 	var inputReader io.Reader
 	if len(files) > 0 {
+		files, err := expandInputDirs(files)
+		if err != nil {
+			return err
+		}
 		var combined strings.Builder
-		for _, path := range files {
+		for i, path := range files {
 			data, err := os.ReadFile(path)
 			if err != nil {
 				return fmt.Errorf("reading %s: %w", path, err)
 			}
+			if i > 0 {
+				// A file without a final newline must not run into
+				// the first line of the next.
+				combined.WriteString("\n")
+			}
 			combined.WriteString(extractTranslationText(data))
 		}
 		inputReader = strings.NewReader(combined.String())
@@ -66,7 +192,7 @@ func reportMerge(root, locale string, files []string) error {
 	}
 
 	// Parse new entries.
-	newEntries, err := parseMergeInput(inputReader)
+	newEntries, err := parseMergeInput(inputReader, opts.commentPrefixes)
 	if err != nil {
 		return err
 	}
@@ -75,15 +201,56 @@ func reportMerge(root, locale string, files []string) error {
 		return fmt.Errorf("no translation entries found in input")
 	}
 
-	// Build merged entry list: existing + new (new entries override existing).
+	if opts.reportConflicts {
+		printMergeConflicts(os.Stderr, findMergeConflicts(existing, newEntries))
+	}
+
+	var enKeys map[string]string
+	if opts.validateSource || opts.dropIdentical {
+		enKeys, err = loadYAMLFlat(enPath)
+		if err != nil {
+			return err
+		}
+	}
+	if opts.validateSource {
+		if unknown := unknownSourceKeys(newEntries, enKeys); len(unknown) > 0 {
+			fmt.Fprintf(os.Stderr, "Found %d incoming keys not in en-us.yaml:\n", len(unknown))
+			for _, k := range unknown {
+				fmt.Fprintf(os.Stderr, "  %s\n", k)
+			}
+			if opts.strict {
+				return fmt.Errorf("refusing to merge %d keys not in en-us.yaml", len(unknown))
+			}
+		}
+	}
+
+	// Build merged entry list: existing + new (new entries override existing
+	// unless --no-overwrite protects a non-empty existing value).
 	merged := make(map[string]mergeEntry, len(existing)+len(newEntries))
 	for k, e := range existing {
 		merged[k] = e
 	}
-	added := 0
+	added, unchanged, skipped, dropped := 0, 0, 0, 0
 	for _, e := range newEntries {
-		if _, exists := merged[e.key]; !exists {
+		old, exists := merged[e.key]
+		if en, ok := enKeys[e.key]; opts.dropIdentical && ok && e.value == en {
+			// An untranslated value is no translation; an existing real
+			// translation is kept, an existing English copy removed.
+			if exists && old.value == en {
+				delete(merged, e.key)
+			}
+			dropped++
+			continue
+		}
+		if !exists {
 			added++
+		} else if opts.noOverwrite && strings.TrimSpace(old.value) != "" {
+			skipped++
+			continue
+		} else if !opts.exact && old.value != e.value && sameIgnoringTrailingSpace(old.value, e.value) {
+			// Keep the existing entry so the file does not churn.
+			unchanged++
+			continue
 		}
 		merged[e.key] = e
 	}
@@ -94,18 +261,140 @@ func reportMerge(root, locale string, files []string) error {
 		entries = append(entries, e)
 	}
 
-	// Write nested YAML.
+	// Follow en-us.yaml key order if requested.
+	var order []string
+	if opts.matchSource {
+		order, err = loadYAMLKeyOrder(enPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write nested YAML, or JSON for a .json locale file.
 	var buf strings.Builder
-	writeNestedYAML(&buf, entries)
+	writeOpts := yamlWriteOptions{order: order, blankDepth: opts.blankDepth, wrap: opts.wrap}
+	if isJSONFile(localePath) {
+		writeNestedJSON(&buf, entries, writeOpts)
+	} else {
+		writeNestedYAML(&buf, entries, writeOpts)
+	}
 
-	if err := os.WriteFile(localePath, []byte(buf.String()), 0644); err != nil {
-		return fmt.Errorf("writing %s: %w", localePath, err)
+	// In dry-run mode the result goes to a temporary file, so --validate
+	// can still check it without touching the locale file.
+	writtenPath := localePath
+	if opts.dryRun {
+		tmp, err := os.CreateTemp("", "i18n-merge-*"+filepath.Ext(localePath))
+		if err != nil {
+			return err
+		}
+		tmp.Close()
+		defer os.Remove(tmp.Name())
+		writtenPath = tmp.Name()
+	} else if opts.backup {
+		if err := backupFile(localePath); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(writtenPath, []byte(buf.String()), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", writtenPath, err)
 	}
 
-	fmt.Fprintf(os.Stderr, "Merged %d new keys into %s (total: %d keys)\n", added, localePath, len(entries))
+	if !opts.quiet {
+		if opts.dryRun {
+			fmt.Fprintf(os.Stderr, "Would merge %d new keys into %s (total: %d keys)\n", added, localePath, len(entries))
+		} else {
+			fmt.Fprintf(os.Stderr, "Merged %d new keys into %s (total: %d keys)\n", added, localePath, len(entries))
+		}
+		if unchanged > 0 {
+			fmt.Fprintf(os.Stderr, "Kept %d existing values that differ only in trailing whitespace (use --exact to overwrite)\n", unchanged)
+		}
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "Skipped %d incoming values for keys that already have a translation (--no-overwrite)\n", skipped)
+		}
+		if dropped > 0 {
+			fmt.Fprintf(os.Stderr, "Dropped %d incoming values identical to en-us.yaml (--drop-identical)\n", dropped)
+		}
+	}
+
+	if opts.validate {
+		problems, warnings, err := validateMergedLocale(enPath, writtenPath, locale, existing, opts.rtlLocales)
+		if err != nil {
+			return err
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "  warning: %s\n", w)
+		}
+		if len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Fprintf(os.Stderr, "  %s\n", p)
+			}
+			return fmt.Errorf("validation of %s found %d problems", localePath, len(problems))
+		}
+		if !opts.quiet {
+			fmt.Fprintf(os.Stderr, "Validated %s: no problems found\n", localePath)
+		}
+	}
 	return nil
 }
 
+// validateMergedLocale re-reads a freshly written locale file and checks
+// it against en-us.yaml. It reports placeholder mismatches and stale keys
+// that were not already stale before the merge (existing holds the
+// pre-merge entries). For the right-to-left locales in rtl (see
+// isRTLLocale) it also warns when positional placeholders are reordered.
+// A file that no longer parses is returned as an error.
+func validateMergedLocale(enPath, localePath, locale string, existing map[string]mergeEntry, rtl []string) (problems, warnings []string, err error) {
+	enKeys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("re-reading merged file: %w", err)
+	}
+
+	for _, k := range sortedKeys(localeKeys) {
+		enValue, found := enKeys[k]
+		if !found {
+			if _, wasStale := existing[k]; !wasStale {
+				problems = append(problems, fmt.Sprintf("%s: not in en-us.yaml (new stale key)", k))
+			}
+			continue
+		}
+		missing, extra := placeholderDiff(enValue, localeKeys[k])
+		var details []string
+		if len(missing) > 0 {
+			details = append(details, "missing "+strings.Join(missing, ", "))
+		}
+		if len(extra) > 0 {
+			details = append(details, "unknown "+strings.Join(extra, ", "))
+		}
+		if len(details) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: placeholder mismatch (%s)", k, strings.Join(details, "; ")))
+		}
+		if isRTLLocale(locale, rtl) && positionalOrderChanged(enValue, localeKeys[k]) {
+			warnings = append(warnings, fmt.Sprintf("%s: positional placeholders reordered", k))
+		}
+	}
+
+	// Typed values kept from a hand-edited file (see reportValidate).
+	if !isJSONFile(localePath) {
+		enDefs, err := loadYAMLFlatWithLines(enPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		defs, err := loadYAMLFlatWithLines(localePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("re-reading merged file: %w", err)
+		}
+		for _, h := range findTypedScalars(defs, enDefs) {
+			problems = append(problems, fmt.Sprintf("%s: %s is a %s, not a string (write %s)", h.Key, h.Value, h.Type, h.Fix))
+		}
+	}
+	return problems, warnings, nil
+}
+
 // extractTranslationText extracts flat translation content from raw bytes.
 // It handles three input formats:
 //  1. JSONL agent output — parses JSON, extracts text from assistant messages
@@ -183,9 +472,14 @@ func extractTranslationText(data []byte) string {
 }
 
 // parseMergeInput reads flat key=value or key: value lines from a reader,
-// collecting @reason comments and associating them with the next key.
-// Blank lines and non-@reason comments are skipped.
-func parseMergeInput(r io.Reader) ([]mergeEntry, error) {
+// collecting comments that start with one of the given annotation
+// prefixes (default any @ annotation, such as @reason or @context) and
+// associating them with the next key.
+// Blank lines and other comments are skipped.
+func parseMergeInput(r io.Reader, prefixes []string) ([]mergeEntry, error) {
+	if prefixes == nil {
+		prefixes = defaultCommentPrefixes
+	}
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
 
@@ -202,15 +496,15 @@ func parseMergeInput(r io.Reader) ([]mergeEntry, error) {
 			continue
 		}
 
-		// Accumulate @reason comments.
-		if strings.HasPrefix(trimmed, "# @reason") {
+		// Accumulate annotation comments.
+		if hasCommentPrefix(trimmed, prefixes) {
 			if pendingComment.Len() > 0 {
 				pendingComment.WriteString("\n")
 			}
 			pendingComment.WriteString(trimmed)
 			continue
 		}
-		// Accumulate continuation lines for multi-line @reason comments.
+		// Accumulate continuation lines for multi-line annotations.
 		if strings.HasPrefix(trimmed, "#   ") && pendingComment.Len() > 0 {
 			pendingComment.WriteString("\n")
 			pendingComment.WriteString(trimmed)
@@ -259,3 +553,68 @@ func parseMergeInput(r io.Reader) ([]mergeEntry, error) {
 	}
 	return entries, nil
 }
+
+// sameIgnoringTrailingSpace reports whether a and b are equal once
+// trailing whitespace is removed from both.
+func sameIgnoringTrailingSpace(a, b string) bool {
+	return strings.TrimRightFunc(a, unicode.IsSpace) == strings.TrimRightFunc(b, unicode.IsSpace)
+}
+
+// mergeConflict is an incoming value that differs from the existing
+// translation of the same key.
+type mergeConflict struct {
+	key      string
+	existing string
+	incoming string
+}
+
+// findMergeConflicts returns, in input order, the incoming entries whose
+// value differs from a non-empty existing translation. Differences only
+// in trailing whitespace are not conflicts.
+func findMergeConflicts(existing map[string]mergeEntry, incoming []mergeEntry) []mergeConflict {
+	var conflicts []mergeConflict
+	for _, e := range incoming {
+		old, exists := existing[e.key]
+		if !exists || strings.TrimSpace(old.value) == "" || sameIgnoringTrailingSpace(old.value, e.value) {
+			continue
+		}
+		conflicts = append(conflicts, mergeConflict{key: e.key, existing: old.value, incoming: e.value})
+	}
+	return conflicts
+}
+
+// unknownSourceKeys returns the sorted, distinct incoming keys that
+// en-us.yaml does not define. Merged as is, they would become stale keys
+// in the locale file.
+func unknownSourceKeys(incoming []mergeEntry, enKeys map[string]string) []string {
+	var unknown []string
+	for _, e := range incoming {
+		if _, found := enKeys[e.key]; !found {
+			unknown = append(unknown, e.key)
+		}
+	}
+	return uniqueSorted(unknown)
+}
+
+// printMergeConflicts lists conflicting values with both versions.
+func printMergeConflicts(w io.Writer, conflicts []mergeConflict) {
+	if len(conflicts) == 0 {
+		fmt.Fprintln(w, "No conflicting values found.")
+		return
+	}
+	fmt.Fprintf(w, "Found %d conflicting values:\n", len(conflicts))
+	for _, c := range conflicts {
+		fmt.Fprintf(w, "  %s\n    existing: %q\n    incoming: %q\n", c.key, c.existing, c.incoming)
+	}
+}
+
+// hasCommentPrefix reports whether a trimmed line is a "# <prefix>"
+// comment for one of the given annotation prefixes.
+func hasCommentPrefix(trimmed string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(trimmed, "# "+p) {
+			return true
+		}
+	}
+	return false
+}
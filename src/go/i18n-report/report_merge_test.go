@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -57,6 +58,30 @@ a.b=hello
 				{key: "a.b", value: "hello", comment: "# @reason Standard translation for admin access;\n#   kept \"sudo\" as-is since it's a Unix command"},
 			},
 		},
+		{
+			name: "@context and @no-translate comments kept",
+			input: `# @context Sidebar entry for the home page
+# @no-translate Rancher Desktop
+a.b=Rancher Desktop Startseite
+# @context Shown in the tray menu
+c.d=Beenden
+`,
+			want: []mergeEntry{
+				{key: "a.b", value: "Rancher Desktop Startseite", comment: "# @context Sidebar entry for the home page\n# @no-translate Rancher Desktop"},
+				{key: "c.d", value: "Beenden", comment: "# @context Shown in the tray menu"},
+			},
+		},
+		{
+			name: "multi-line @context comment with @reason",
+			input: `# @context Button that resets the VM;
+#   destructive, so keep the wording strong
+# @reason Formal register
+a.b=Zurücksetzen
+`,
+			want: []mergeEntry{
+				{key: "a.b", value: "Zurücksetzen", comment: "# @context Button that resets the VM;\n#   destructive, so keep the wording strong\n# @reason Formal register"},
+			},
+		},
 		{
 			name:  "blank lines reset pending comment",
 			input: "# @reason this gets discarded\n\na.b=hello\n",
@@ -65,7 +90,7 @@ a.b=hello
 			},
 		},
 		{
-			name:  "non-@reason comments are skipped",
+			name:  "non-annotation comments are skipped",
 			input: "# just a comment\na.b=hello\n",
 			want: []mergeEntry{
 				{key: "a.b", value: "hello"},
@@ -103,7 +128,7 @@ a.b=hello
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := parseMergeInput(strings.NewReader(tc.input))
+			got, err := parseMergeInput(strings.NewReader(tc.input), nil)
 			if tc.wantErr && err == nil {
 				t.Fatal("expected error, got nil")
 			}
@@ -157,7 +182,7 @@ status.done=Fertig
 	inputFile := filepath.Join(dir, "input.txt")
 	os.WriteFile(inputFile, []byte(newInput), 0644)
 
-	err := reportMerge(dir, "de", []string{inputFile})
+	err := reportMerge(dir, "de", []string{inputFile}, mergeOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -227,3 +252,319 @@ Some text after
 		})
 	}
 }
+
+func TestMergeValidate(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	enUS := `tray:
+  quit: Quit {appName}
+  status: Running
+`
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte(enUS), 0644)
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte("old:\n  key: Alt\n"), 0644)
+
+	inputFile := filepath.Join(dir, "input.txt")
+
+	// Valid input passes; the pre-existing stale key is not reported.
+	os.WriteFile(inputFile, []byte("tray.quit={appName} beenden\ntray.status=Läuft\n"), 0644)
+	if err := reportMerge(dir, "de", []string{inputFile}, mergeOptions{validate: true}); err != nil {
+		t.Fatalf("valid merge failed validation: %v", err)
+	}
+
+	// A renamed placeholder and a new stale key both fail.
+	os.WriteFile(inputFile, []byte("tray.quit={name} beenden\ntray.extra=Neu\n"), 0644)
+	err := reportMerge(dir, "de", []string{inputFile}, mergeOptions{validate: true})
+	if err == nil || !strings.Contains(err.Error(), "2 problems") {
+		t.Errorf("expected 2 validation problems, got %v", err)
+	}
+	// A hand-written unquoted boolean kept from the existing file fails.
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte("tray:\n  status: true\n"), 0644)
+	os.WriteFile(inputFile, []byte("tray.quit={appName} beenden\n"), 0644)
+	err = reportMerge(dir, "de", []string{inputFile}, mergeOptions{validate: true})
+	if err == nil || !strings.Contains(err.Error(), "1 problems") {
+		t.Errorf("expected 1 validation problem, got %v", err)
+	}
+}
+
+func TestValidateMergedLocaleRTLLocales(t *testing.T) {
+	dir := t.TempDir()
+	enPath := filepath.Join(dir, "en-us.yaml")
+	ckbPath := filepath.Join(dir, "ckb.yaml")
+	os.WriteFile(enPath, []byte("page: '{0} of {1}'\n"), 0644)
+	os.WriteFile(ckbPath, []byte("page: '{1} of {0}'\n"), 0644)
+
+	// ckb is not right-to-left by default, only once --rtl-locales names it.
+	for _, tt := range []struct {
+		rtl  []string
+		want int
+	}{
+		{nil, 0},
+		{parseRTLLocales("ar,ckb"), 1},
+	} {
+		problems, warnings, err := validateMergedLocale(enPath, ckbPath, "ckb", nil, tt.rtl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(problems) != 0 || len(warnings) != tt.want {
+			t.Errorf("rtl %q: problems %q, warnings %q, want %d warnings", tt.rtl, problems, warnings, tt.want)
+		}
+	}
+}
+
+func TestMergeDryRun(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("tray:\n  quit: Quit {appName}\n"), 0644)
+	existingDE := "tray:\n  status: Läuft\n"
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte(existingDE), 0644)
+
+	inputFile := filepath.Join(dir, "input.txt")
+	os.WriteFile(inputFile, []byte("tray.quit={name} beenden\n"), 0644)
+
+	// Validation still runs against the would-be output.
+	err := reportMerge(dir, "de", []string{inputFile}, mergeOptions{dryRun: true, validate: true})
+	if err == nil || !strings.Contains(err.Error(), "1 problems") {
+		t.Errorf("expected validation failure in dry run, got %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(transDir, "de.yaml"))
+	if string(data) != existingDE {
+		t.Errorf("dry run rewrote the locale file:\n%s", data)
+	}
+}
+
+func TestParseMergeInputCommentPrefix(t *testing.T) {
+	input := "# NOTE: formal register\n#   second line\n# @reason ignored\nnav.home=Startseite\n"
+
+	got, err := parseMergeInput(strings.NewReader(input), parseCommentPrefixes("# NOTE:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d entries, want 1", len(got))
+	}
+	want := "# NOTE: formal register\n#   second line"
+	if got[0].comment != want {
+		t.Errorf("comment = %q, want %q", got[0].comment, want)
+	}
+
+	got, err = parseMergeInput(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].comment != "# @reason ignored" {
+		t.Errorf("default prefixes: comment = %q", got[0].comment)
+	}
+}
+
+func TestMergeTrailingWhitespaceNoOp(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("tray:\n  quit: Quit\n  status: Running\n"), 0644)
+	existingDE := "tray:\n  # @reason keep\n  status: Läuft\n"
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte(existingDE), 0644)
+
+	inputFile := filepath.Join(dir, "input.txt")
+	os.WriteFile(inputFile, []byte("tray.status: 'Läuft  '\n"), 0644)
+
+	if err := reportMerge(dir, "de", []string{inputFile}, mergeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(transDir, "de.yaml"))
+	if string(data) != existingDE {
+		t.Errorf("trailing-space change rewrote the value:\n%s", data)
+	}
+
+	if err := reportMerge(dir, "de", []string{inputFile}, mergeOptions{exact: true}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(filepath.Join(transDir, "de.yaml"))
+	if !strings.Contains(string(data), "'Läuft  '") {
+		t.Errorf("--exact did not overwrite the value:\n%s", data)
+	}
+}
+
+func TestMergeNoOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("tray:\n  about: About\n  quit: Quit\n  status: Running\n  title: Tray\n"), 0644)
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte("tray:\n  about: Über\n  status: Läuft\n  title: ''\n"), 0644)
+
+	inputFile := filepath.Join(dir, "input.txt")
+	os.WriteFile(inputFile, []byte("tray.about: Über\ntray.status: Aktiv\ntray.title: Leiste\ntray.quit: Beenden\n"), 0644)
+
+	stderr, err := captureStderr(t, func() error {
+		return reportMerge(dir, "de", []string{inputFile}, mergeOptions{noOverwrite: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both protected keys count, whether or not the incoming value differs.
+	if !strings.Contains(stderr, "Skipped 2 incoming values") {
+		t.Errorf("stderr = %q, want 2 skipped values", stderr)
+	}
+	got, err := loadYAMLFlat(filepath.Join(transDir, "de.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"tray.about": "Über", "tray.status": "Läuft", "tray.title": "Leiste", "tray.quit": "Beenden"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMergeDropIdentical(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("tray:\n  quit: Quit\n  status: Running\n  title: Tray\n  version: Version\n"), 0644)
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte("tray:\n  status: Läuft\n  title: Tray\n"), 0644)
+
+	// status and title come back in English: the real translation of
+	// status stays, the English copy of title goes.
+	inputFile := filepath.Join(dir, "input.txt")
+	os.WriteFile(inputFile, []byte("tray.status: Running\ntray.title: Tray\ntray.quit: Beenden\ntray.version: Version\n"), 0644)
+
+	stderr, err := captureStderr(t, func() error {
+		return reportMerge(dir, "de", []string{inputFile}, mergeOptions{dropIdentical: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadYAMLFlat(filepath.Join(transDir, "de.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"tray.status": "Läuft", "tray.quit": "Beenden"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !strings.Contains(stderr, "Dropped 3 incoming values identical to en-us.yaml") {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
+
+func TestFindMergeConflicts(t *testing.T) {
+	existing := map[string]mergeEntry{
+		"tray.status": {key: "tray.status", value: "Läuft"},
+		"tray.quit":   {key: "tray.quit", value: "Beenden"},
+		"tray.title":  {key: "tray.title", value: ""},
+		"tray.help":   {key: "tray.help", value: "Hilfe"},
+	}
+	incoming := []mergeEntry{
+		{key: "tray.status", value: "Aktiv"},
+		{key: "tray.quit", value: "Beenden"},
+		{key: "tray.title", value: "Leiste"},
+		{key: "tray.help", value: "Hilfe "},
+		{key: "tray.new", value: "Neu"},
+	}
+	got := findMergeConflicts(existing, incoming)
+	want := []mergeConflict{{key: "tray.status", existing: "Läuft", incoming: "Aktiv"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMergeInputDir(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("tray:\n  quit: Quit\n  status: Running\n"), 0644)
+
+	// Batches are merged in file name order, so the later one wins.
+	batches := filepath.Join(dir, "batches")
+	os.MkdirAll(filepath.Join(batches, "nested"), 0755)
+	os.WriteFile(filepath.Join(batches, "02.txt"), []byte("tray.quit: Beenden\n"), 0644)
+	os.WriteFile(filepath.Join(batches, "01.txt"), []byte("tray.quit: Verlassen\ntray.status: Läuft\n"), 0644)
+	os.WriteFile(filepath.Join(batches, ".hidden"), []byte("tray.hidden: Versteckt\n"), 0644)
+	os.WriteFile(filepath.Join(batches, "nested", "03.txt"), []byte("tray.nested: Verschachtelt\n"), 0644)
+
+	if err := reportMerge(dir, "de", []string{batches}, mergeOptions{quiet: true}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadYAMLFlat(filepath.Join(transDir, "de.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"tray.quit": "Beenden", "tray.status": "Läuft"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	os.MkdirAll(empty, 0755)
+	if err := reportMerge(dir, "de", []string{empty}, mergeOptions{quiet: true}); err == nil {
+		t.Error("expected an error for a directory without input files")
+	}
+}
+
+func TestMergeInputDirNoTrailingNewline(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("tray:\n  quit: Quit\n  status: Running\n"), 0644)
+
+	batches := filepath.Join(dir, "batches")
+	os.MkdirAll(batches, 0755)
+	os.WriteFile(filepath.Join(batches, "01.txt"), []byte("tray.quit: Beenden"), 0644)
+	os.WriteFile(filepath.Join(batches, "02.txt"), []byte("tray.status: Läuft\n"), 0644)
+
+	if err := reportMerge(dir, "de", []string{batches}, mergeOptions{quiet: true}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadYAMLFlat(filepath.Join(transDir, "de.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"tray.quit": "Beenden", "tray.status": "Läuft"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMergeValidateAgainstSource(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte("tray:\n  quit: Quit\n"), 0644)
+
+	inputFile := filepath.Join(dir, "input.txt")
+	os.WriteFile(inputFile, []byte("tray.quit: Beenden\ntray.invented: Erfunden\n"), 0644)
+
+	stderr, err := captureStderr(t, func() error {
+		return reportMerge(dir, "de", []string{inputFile}, mergeOptions{validateSource: true, strict: true, quiet: true})
+	})
+	if err == nil {
+		t.Fatal("expected --strict to reject the merge")
+	}
+	if !strings.Contains(stderr, "  tray.invented\n") {
+		t.Errorf("stderr does not list the unknown key:\n%s", stderr)
+	}
+	if _, err := os.Stat(filepath.Join(transDir, "de.yaml")); !os.IsNotExist(err) {
+		t.Error("locale file was written despite --strict")
+	}
+
+	// Without --strict the keys are reported but still merged.
+	if _, err := captureStderr(t, func() error {
+		return reportMerge(dir, "de", []string{inputFile}, mergeOptions{validateSource: true, quiet: true})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadYAMLFlat(filepath.Join(transDir, "de.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"tray.quit": "Beenden", "tray.invented": "Erfunden"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
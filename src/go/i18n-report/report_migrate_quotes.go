@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func runMigrateQuotes(args []string) error {
+	fs := flag.NewFlagSet("migrate-quotes", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code of the file to rewrite (required)")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportMigrateQuotes(root, *locale)
+}
+
+// reportMigrateQuotes rewrites a locale file so every string value is
+// single-quoted where possible, keeping comments and key order. Running it
+// again leaves the file unchanged.
+func reportMigrateQuotes(root, locale string) error {
+	path, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+
+	old, quoted, err := renderLocaleFile(path, yamlWriteOptions{singleQuotes: true})
+	if err != nil {
+		return err
+	}
+	if old == quoted {
+		fmt.Fprintf(os.Stderr, "%s already uses the preferred quoting\n", path)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(quoted), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Fprintf(os.Stderr, "Rewrote %s with single-quoted values\n", path)
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSingleQuotedScalar(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Startseite", "'Startseite'"},
+		{"it's done", `"it's done"`},
+		{`it's a "test"`, `'it''s a "test"'`},
+		{"yes", "'yes'"},
+		{"tab\there", `"tab\there"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			if got := singleQuotedScalar(tc.input); got != tc.want {
+				t.Errorf("singleQuotedScalar(%q) = %s, want %s", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReportMigrateQuotes(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+	path := filepath.Join(transDir, "de.yaml")
+
+	input := "nav:\n" +
+		"  # @reason formal\n" +
+		"  home: \"Startseite\"\n" +
+		"  about: Über\n" +
+		"  quote: \"Das ist's\"\n" +
+		"  port: 8080\n"
+	os.WriteFile(path, []byte(input), 0644)
+
+	if err := reportMigrateQuotes(dir, "de"); err != nil {
+		t.Fatal(err)
+	}
+	want := "nav:\n" +
+		"  # @reason formal\n" +
+		"  home: 'Startseite'\n" +
+		"  about: 'Über'\n" +
+		"  quote: \"Das ist's\"\n" +
+		"  port: 8080\n"
+	data, _ := os.ReadFile(path)
+	if string(data) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", data, want)
+	}
+
+	// A second run is a no-op.
+	if err := reportMigrateQuotes(dir, "de"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	if string(data) != want {
+		t.Errorf("second run changed the file:\n%s", data)
+	}
+	keys, err := loadYAMLFlat(path)
+	if err != nil || keys["nav.quote"] != "Das ist's" {
+		t.Errorf("values changed: %v (%v)", keys, err)
+	}
+}
@@ -3,14 +3,23 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 )
 
 func runMissing(args []string) error {
 	fs := flag.NewFlagSet("missing", flag.ExitOnError)
 	locale := fs.String("locale", "", "Target locale code (required)")
-	format := fs.String("format", "text", "Output format: text, json")
+	format := fs.String("format", "text", "Output format: text, json, yaml")
+	rich := fs.Bool("rich", false, "In JSON output, include the en-us and locale values of each key")
+	fallback := fs.Bool("fallback", false, "Count keys provided by parent locales (de for de-at) as present")
+	source := sourceLocaleFlag(fs)
 	fs.Parse(args)
 
+	if err := checkFormat(*format, "text", "json", "yaml"); err != nil {
+		return err
+	}
+
 	if *locale == "" {
 		return fmt.Errorf("--locale is required")
 	}
@@ -19,21 +28,35 @@ func runMissing(args []string) error {
 	if err != nil {
 		return err
 	}
-	return reportMissing(root, *locale, *format)
+	return reportMissing(root, *locale, *format, missingOptions{rich: *rich, fallback: *fallback, sourceLocale: *source})
 }
 
-func reportMissing(root, locale, format string) error {
-	enPath := translationsPath(root, "en-us.yaml")
-	localePath := translationsPath(root, locale+".yaml")
+// missingOptions holds the optional behaviors of the missing subcommand.
+type missingOptions struct {
+	rich         bool   // include values in JSON output
+	fallback     bool   // resolve keys through the parent locale chain
+	sourceLocale string // locale listing the keys to expect; empty for en-us
+}
 
+func reportMissing(root, locale, format string, opts missingOptions) error {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return err
+	}
 	enKeys, err := loadYAMLFlat(enPath)
 	if err != nil {
 		return err
 	}
-	localeKeys, err := loadYAMLFlat(localePath)
+
+	chain := []string{locale}
+	if opts.fallback {
+		chain = fallbackChain(locale)
+	}
+	localeKeys, err := loadLocaleChain(root, chain)
 	if err != nil {
 		return err
 	}
+
 	var missing []string
 	for _, k := range sortedKeys(enKeys) {
 		if _, found := localeKeys[k]; !found {
@@ -41,5 +64,53 @@ func reportMissing(root, locale, format string) error {
 		}
 	}
 
-	return outputStrings(missing, format, "missing keys in "+locale)
+	if opts.rich && format == "json" {
+		return outputKeyValues(keyValues(missing, enKeys, localeKeys))
+	}
+	label := "missing keys in " + locale
+	if len(chain) > 1 {
+		label += " (falling back to " + strings.Join(chain[1:], ", ") + ")"
+	}
+	return outputStrings(missing, format, label)
+}
+
+// fallbackChain returns a locale followed by the parents vue-i18n falls
+// back to before English, most specific first: "zh-hans-cn" yields
+// "zh-hans-cn", "zh-hans", "zh".
+func fallbackChain(locale string) []string {
+	chain := []string{locale}
+	for {
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			return chain
+		}
+		locale = locale[:i]
+		chain = append(chain, locale)
+	}
+}
+
+// loadLocaleChain loads the flattened keys of each locale in chain, with
+// earlier locales taking precedence. The first locale must exist; parents
+// without a translation file are skipped.
+func loadLocaleChain(root string, chain []string) (map[string]string, error) {
+	merged := make(map[string]string)
+	for i, locale := range chain {
+		path, err := findLocaleFile(root, locale)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := loadYAMLFlat(path)
+		if err != nil {
+			if i > 0 && os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for k, v := range keys {
+			if _, found := merged[k]; !found {
+				merged[k] = v
+			}
+		}
+	}
+	return merged, nil
 }
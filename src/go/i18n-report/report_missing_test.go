@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFallbackChain(t *testing.T) {
+	for locale, want := range map[string][]string{
+		"de":         {"de"},
+		"de-at":      {"de-at", "de"},
+		"zh-hans-cn": {"zh-hans-cn", "zh-hans", "zh"},
+	} {
+		if got := fallbackChain(locale); !reflect.DeepEqual(got, want) {
+			t.Errorf("fallbackChain(%q) = %v, want %v", locale, got, want)
+		}
+	}
+}
+
+func TestReportMissingFallback(t *testing.T) {
+	dir := writeCheckFixture(t)
+	os.WriteFile(filepath.Join(dir, translationsDir, "de-at.yaml"), []byte("nav:\n  about: Über\n"), 0644)
+
+	missingKeys := func(opts missingOptions) []string {
+		t.Helper()
+		out, err := captureStdout(t, func() error {
+			return reportMissing(dir, "de-at", "json", opts)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var keys []string
+		if err := json.Unmarshal([]byte(out), &keys); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		return keys
+	}
+
+	if got, want := missingKeys(missingOptions{}), []string{"nav.home"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("without fallback: got %v, want %v", got, want)
+	}
+	// nav.home comes from de.yaml.
+	if got := missingKeys(missingOptions{fallback: true}); len(got) != 0 {
+		t.Errorf("with fallback: got %v, want none", got)
+	}
+}
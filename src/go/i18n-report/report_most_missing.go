@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+func runMostMissing(args []string) error {
+	fs := flag.NewFlagSet("most-missing", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	limit := fs.Int("limit", 0, "Show only the first N keys (0 for all)")
+	scanOpts := scanFlags(fs)
+	fs.Parse(args)
+
+	scan, err := scanOpts()
+	if err != nil {
+		return err
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportMostMissing(root, *format, *limit, scan)
+}
+
+// missingEverywhere is a used key with the locales that lack it.
+type missingEverywhere struct {
+	Key     string   `json:"key"`
+	Value   string   `json:"value"`
+	Count   int      `json:"count"`
+	Locales []string `json:"locales"`
+}
+
+// reportMostMissing ranks the keys referenced in source by how many
+// locales lack a translation, so the keys missing everywhere can be
+// translated first.
+func reportMostMissing(root, format string, limit int, opts scanOptions) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	refs, err := findKeyReferences(root, enKeys, opts)
+	if err != nil {
+		return err
+	}
+
+	paths, err := findTranslationFiles(root)
+	if err != nil {
+		return err
+	}
+	locales := make(map[string]map[string]string)
+	for _, path := range paths {
+		locale := localeName(path)
+		if locale == "en-us" {
+			continue
+		}
+		keys, err := loadYAMLFlat(path)
+		if err != nil {
+			return err
+		}
+		locales[locale] = keys
+	}
+
+	ranked := rankMissingKeys(enKeys, refs, locales)
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ranked)
+	}
+
+	if len(ranked) == 0 {
+		fmt.Printf("No used keys are missing from any of %d locales.\n", len(locales))
+		return nil
+	}
+
+	fmt.Printf("Found %d used keys missing from at least one of %d locales:\n", len(ranked), len(locales))
+	for _, m := range ranked {
+		fmt.Printf("  %s (%d/%d: %s)\n    %s\n", m.Key, m.Count, len(locales), strings.Join(m.Locales, ", "), m.Value)
+	}
+	return nil
+}
+
+// rankMissingKeys returns the referenced English keys missing from at
+// least one locale, ordered by the number of locales lacking them (most
+// first), then by key.
+func rankMissingKeys(enKeys map[string]string, refs map[string][]keyReference, locales map[string]map[string]string) []missingEverywhere {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ranked []missingEverywhere
+	for _, k := range sortedKeys(enKeys) {
+		if _, used := refs[k]; !used {
+			continue
+		}
+		var lacking []string
+		for _, name := range names {
+			if _, found := locales[name][k]; !found {
+				lacking = append(lacking, name)
+			}
+		}
+		if len(lacking) == 0 {
+			continue
+		}
+		ranked = append(ranked, missingEverywhere{Key: k, Value: enKeys[k], Count: len(lacking), Locales: lacking})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Count > ranked[j].Count
+	})
+	return ranked
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRankMissingKeys(t *testing.T) {
+	enKeys := map[string]string{
+		"nav.home":   "Home",
+		"nav.about":  "About",
+		"nav.help":   "Help",
+		"nav.unused": "Unused",
+	}
+	refs := map[string][]keyReference{
+		"nav.home":  {{File: "a.vue", Line: 1}},
+		"nav.about": {{File: "a.vue", Line: 2}},
+		"nav.help":  {{File: "a.vue", Line: 3}},
+	}
+	locales := map[string]map[string]string{
+		"de": {"nav.home": "Startseite"},
+		"fa": {"nav.home": "خانه", "nav.help": "راهنما"},
+	}
+
+	got := rankMissingKeys(enKeys, refs, locales)
+	want := []missingEverywhere{
+		{Key: "nav.about", Value: "About", Count: 2, Locales: []string{"de", "fa"}},
+		{Key: "nav.help", Value: "Help", Count: 1, Locales: []string{"de"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
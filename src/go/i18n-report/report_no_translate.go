@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func runNoTranslate(args []string) error {
+	fs := flag.NewFlagSet("no-translate", flag.ExitOnError)
+	locale := fs.String("locale", "", "Target locale code (required)")
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportNoTranslate(root, *locale, *format)
+}
+
+// noTranslateHit is a translation that lost terms marked @no-translate.
+type noTranslateHit struct {
+	Key     string   `json:"key"`
+	Missing []string `json:"missing"`
+	Value   string   `json:"value"`
+}
+
+// reportNoTranslate checks that every term listed in a key's
+// "# @no-translate" annotation in en-us.yaml appears verbatim in the
+// translation.
+func reportNoTranslate(root, locale, format string) error {
+	enEntries, err := loadYAMLWithComments(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	hits := findNoTranslateViolations(enEntries, localeKeys)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Printf("All @no-translate terms are kept in %s.\n", locale)
+		return nil
+	}
+
+	fmt.Printf("Found %d keys in %s missing @no-translate terms:\n", len(hits), locale)
+	for _, h := range hits {
+		fmt.Printf("  %s: %s\n    %s\n", h.Key, strings.Join(h.Missing, ", "), h.Value)
+	}
+	return nil
+}
+
+// findNoTranslateViolations returns the translated keys whose value does
+// not contain every @no-translate term of the English entry.
+func findNoTranslateViolations(enEntries map[string]mergeEntry, localeKeys map[string]string) []noTranslateHit {
+	var hits []noTranslateHit
+	for _, k := range sortedKeys(localeKeys) {
+		entry, found := enEntries[k]
+		if !found {
+			continue
+		}
+		value := localeKeys[k]
+		terms := noTranslateTerms(parseAnnotations(entry.comment)["no-translate"])
+		var missing []string
+		for _, term := range terms {
+			if !strings.Contains(value, term) {
+				missing = append(missing, term)
+			}
+		}
+		if len(missing) > 0 {
+			hits = append(hits, noTranslateHit{Key: k, Missing: missing, Value: value})
+		}
+	}
+	return hits
+}
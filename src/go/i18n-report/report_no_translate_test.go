@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindNoTranslateViolations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "en-us.yaml")
+	en := `engine:
+  # @no-translate containerd, moby
+  label: Use containerd or moby
+  # @no-translate — Unix command name
+  sudo: Run sudo
+  plain: Hello
+`
+	if err := os.WriteFile(path, []byte(en), 0644); err != nil {
+		t.Fatal(err)
+	}
+	enEntries, err := loadYAMLWithComments(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localeKeys := map[string]string{
+		"engine.label": "Containerd oder moby verwenden",
+		"engine.sudo":  "Sudo ausführen",
+		"engine.plain": "Hallo",
+	}
+
+	got := findNoTranslateViolations(enEntries, localeKeys)
+	want := []noTranslateHit{
+		{Key: "engine.label", Missing: []string{"containerd"}, Value: "Containerd oder moby verwenden"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func runNormalize(args []string) error {
+	fs := flag.NewFlagSet("normalize", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code of the file to rewrite (required)")
+	check := fs.Bool("check", false, "Do not rewrite; fail if the file is not already normalized")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportNormalize(root, *locale, *check)
+}
+
+// reportNormalize rewrites a locale file in the layout the merge command
+// produces: values quoted only where yamlScalar needs it, comments and key
+// order kept. With check, the file is left alone and an error is returned
+// if rewriting it would change anything.
+func reportNormalize(root, locale string, check bool) error {
+	path, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	old, formatted, err := renderLocaleFile(path, yamlWriteOptions{})
+	if err != nil {
+		return err
+	}
+	if old == formatted {
+		fmt.Fprintf(os.Stderr, "%s is already normalized\n", path)
+		return nil
+	}
+	if check {
+		return fmt.Errorf("%s is not normalized (run i18n-report normalize --locale=%s)", path, locale)
+	}
+	if err := os.WriteFile(path, []byte(formatted), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Fprintf(os.Stderr, "Normalized %s\n", path)
+	return nil
+}
+
+// renderLocaleFile reads a locale file and returns its current contents
+// along with the contents writeNestedYAML produces for it under opts.
+// Comments are kept and keys stay in file order. JSON files have no
+// quoting to normalize and are rejected.
+func renderLocaleFile(path string, opts yamlWriteOptions) (string, string, error) {
+	if isJSONFile(path) {
+		return "", "", fmt.Errorf("%s is a JSON file; only YAML locale files can be re-rendered", path)
+	}
+	entries, err := loadYAMLWithComments(path)
+	if err != nil {
+		return "", "", err
+	}
+	order, err := loadYAMLKeyOrder(path)
+	if err != nil {
+		return "", "", err
+	}
+	list := make([]mergeEntry, 0, len(entries))
+	for _, e := range entries {
+		list = append(list, e)
+	}
+
+	var buf strings.Builder
+	opts.order = order
+	writeNestedYAML(&buf, list, opts)
+
+	old, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	return string(old), buf.String(), nil
+}
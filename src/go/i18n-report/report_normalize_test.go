@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportNormalize(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+	path := filepath.Join(transDir, "de.yaml")
+
+	input := "nav:\n" +
+		"  # @reason formal\n" +
+		"  home: 'Startseite'\n" +
+		"  about: \"Über\"\n" +
+		"  colon: 'Hinweis: neu'\n" +
+		"  port: 8080\n"
+	os.WriteFile(path, []byte(input), 0644)
+
+	if err := reportNormalize(dir, "de", true); err == nil {
+		t.Error("--check passed on a file that is not normalized")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != input {
+		t.Fatalf("--check modified the file:\n%s", data)
+	}
+
+	if err := reportNormalize(dir, "de", false); err != nil {
+		t.Fatal(err)
+	}
+	want := "nav:\n" +
+		"  # @reason formal\n" +
+		"  home: Startseite\n" +
+		"  about: Über\n" +
+		"  colon: 'Hinweis: neu'\n" +
+		"  port: 8080\n"
+	data, _ = os.ReadFile(path)
+	if string(data) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", data, want)
+	}
+
+	if err := reportNormalize(dir, "de", true); err != nil {
+		t.Errorf("--check failed on a normalized file: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+func runPlaceholders(args []string) error {
+	fs := flag.NewFlagSet("placeholders", flag.ExitOnError)
+	locale := fs.String("locale", "", "Target locale code (required)")
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportPlaceholders(root, *locale, *format)
+}
+
+// foreignPlaceholder records a translation that uses placeholder names
+// the English value does not define, typically because the translator
+// translated the name itself (e.g. {count} became {Anzahl}).
+type foreignPlaceholder struct {
+	Key      string   `json:"key"`
+	Foreign  []string `json:"foreign"`
+	Expected []string `json:"expected"`
+}
+
+// reportPlaceholders lists translations containing placeholders that do
+// not exist in the English value. Such placeholders never bind, so the
+// raw "{name}" text is shown to users.
+func reportPlaceholders(root, locale, format string) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	hits := findForeignPlaceholders(enKeys, localeKeys)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Printf("No foreign placeholders found in %s.\n", locale)
+		return nil
+	}
+
+	fmt.Printf("Found %d keys with foreign placeholders in %s:\n", len(hits), locale)
+	for _, h := range hits {
+		expected := "none"
+		if len(h.Expected) > 0 {
+			expected = "{" + strings.Join(h.Expected, "}, {") + "}"
+		}
+		fmt.Printf("  %s: {%s} (expected %s)\n", h.Key, strings.Join(h.Foreign, "}, {"), expected)
+	}
+	return nil
+}
+
+// findForeignPlaceholders returns the translated keys whose value uses
+// placeholder names absent from the English value, with the names the
+// English value does define.
+func findForeignPlaceholders(enKeys, localeKeys map[string]string) []foreignPlaceholder {
+	var hits []foreignPlaceholder
+	for _, k := range sortedKeys(localeKeys) {
+		enValue, found := enKeys[k]
+		if !found {
+			continue
+		}
+		_, extra := placeholderDiff(enValue, localeKeys[k])
+		if len(extra) == 0 {
+			continue
+		}
+		hits = append(hits, foreignPlaceholder{Key: k, Foreign: extra, Expected: uniqueSorted(placeholderNames(enValue))})
+	}
+	return hits
+}
+
+// uniqueSorted returns the distinct strings of s in sorted order.
+func uniqueSorted(s []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
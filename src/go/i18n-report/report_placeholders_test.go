@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindForeignPlaceholders(t *testing.T) {
+	enKeys := map[string]string{
+		"images.count":  "{count} items",
+		"images.delete": "Delete {name} ({count, plural, one {# tag} other {# tags}})",
+		"images.ok":     "Pull {image}",
+		"images.drop":   "Remove {name}",
+	}
+	localeKeys := map[string]string{
+		"images.count":  "{Anzahl} Artikel",
+		"images.delete": "{name} löschen ({count, plural, one {# Tag} other {# Tags}})",
+		"images.ok":     "{image} holen",
+		"images.drop":   "Entfernen",
+		"images.stale":  "{old}",
+	}
+
+	got := findForeignPlaceholders(enKeys, localeKeys)
+	want := []foreignPlaceholder{
+		{Key: "images.count", Foreign: []string{"Anzahl"}, Expected: []string{"count"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func runPrune(args []string) error {
+	fs := flag.NewFlagSet("prune", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "List the @deprecated keys that would be removed from each file without writing")
+	backup := fs.Bool("backup", false, "Copy each file to <file>.bak before rewriting it, replacing any earlier backup")
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return prune(root, removeOptions{dryRun: *dryRun, backup: *backup})
+}
+
+// prune removes every key annotated "# @deprecated" in en-us.yaml from
+// en-us.yaml and all locale files.
+func prune(root string, opts removeOptions) error {
+	enEntries, err := loadYAMLWithComments(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+
+	keySet := deprecatedKeys(enEntries)
+	if len(keySet) == 0 {
+		fmt.Fprintln(os.Stderr, "No @deprecated keys found.")
+		return nil
+	}
+	return removeKeysFromAll(root, keySet, opts)
+}
+
+// deprecatedKeys returns the keys whose comment carries a @deprecated
+// annotation. The annotation value (a date or a replacement key, by
+// convention) is informational only.
+func deprecatedKeys(enEntries map[string]mergeEntry) map[string]bool {
+	keys := make(map[string]bool)
+	for k, entry := range enEntries {
+		if _, found := parseAnnotations(entry.comment)["deprecated"]; found {
+			keys[k] = true
+		}
+	}
+	return keys
+}
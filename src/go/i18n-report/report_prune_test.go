@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDeprecatedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "en-us.yaml")
+	en := `nav:
+  # @deprecated 2026-09 — use nav.home
+  start: Start
+  home: Home
+  # Shown in the tray menu.
+  # @deprecated
+  quit: Quit
+  # @no-translate Rancher
+  about: About Rancher
+`
+	if err := os.WriteFile(path, []byte(en), 0644); err != nil {
+		t.Fatal(err)
+	}
+	enEntries, err := loadYAMLWithComments(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := deprecatedKeys(enEntries)
+	want := map[string]bool{"nav.start": true, "nav.quit": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
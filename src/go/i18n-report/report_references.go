@@ -5,32 +5,114 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 func runReferences(args []string) error {
 	fs := flag.NewFlagSet("references", flag.ExitOnError)
 	format := fs.String("format", "text", "Output format: text, json")
+	count := fs.Bool("count", false, "Show the number of references per key, most used first")
+	file := fs.String("file", "", "Only show keys used by this source file (path relative to the repository root)")
+	since := fs.String("since", "", "Only scan source files changed since this git ref")
+	singleUse := fs.Bool("single-use", false, "List only keys referenced from exactly one location, as inlining candidates")
+	key := fs.String("key", "", "Show only the references to this key, and the dynamic patterns covering it")
+	exclude := excludeFlag(fs)
+	source := sourceLocaleFlag(fs)
 	fs.Parse(args)
 
+	if err := checkFormat(*format, "text", "json"); err != nil {
+		return err
+	}
+
+	if *singleUse && (*count || *file != "" || *since != "") {
+		return fmt.Errorf("--single-use cannot be combined with --count, --file, or --since")
+	}
+	if *key != "" && (*count || *file != "" || *singleUse) {
+		return fmt.Errorf("--key cannot be combined with --count, --file, or --single-use")
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return err
 	}
-	return reportReferences(root, *format)
+	return reportReferences(root, *format, referencesOptions{count: *count, file: *file, since: *since, singleUse: *singleUse, key: *key, exclude: *exclude, sourceLocale: *source})
 }
 
-func reportReferences(root, format string) error {
-	enPath := translationsPath(root, "en-us.yaml")
+// referencesOptions holds the optional behaviors of the references subcommand.
+type referencesOptions struct {
+	count        bool   // summarize by reference count
+	file         string // restrict to references from this file
+	since        string // git ref; scan only files changed since it
+	singleUse    bool   // list keys with exactly one reference
+	key          string // show only this key's references
+	exclude      []string
+	sourceLocale string // locale whose keys are looked up; empty for en-us
+}
+
+// keyUsage pairs a key's reference count with its locations.
+type keyUsage struct {
+	Count      int            `json:"count"`
+	References []keyReference `json:"references"`
+}
+
+func reportReferences(root, format string, opts referencesOptions) error {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return err
+	}
 	keys, err := loadYAMLFlat(enPath)
 	if err != nil {
 		return err
 	}
 
-	refs, err := findKeyReferences(root, keys)
+	if opts.singleUse {
+		refs, dynamics, err := scanFiles(root, keys, scanOptions{exclude: opts.exclude})
+		if err != nil {
+			return err
+		}
+		return outputSingleUse(singleUseKeys(refs, dynamics, keys), format)
+	}
+
+	scan := scanOptions{exclude: opts.exclude}
+	if opts.since != "" {
+		scan.onlyFiles = sinceFileSet(root, opts.since)
+	}
+
+	if opts.key != "" {
+		if _, found := keys[opts.key]; !found {
+			fmt.Fprintf(os.Stderr, "Warning: %s is not defined in %s\n", opts.key, filepath.Base(enPath))
+		}
+		refs, dynamics, err := scanFiles(root, keys, scan)
+		if err != nil {
+			return err
+		}
+		return outputKeyReferences(opts.key, refs[opts.key], dynamicPatternsForKey(dynamics, opts.key), format)
+	}
+	refs, err := findKeyReferences(root, keys, scan)
 	if err != nil {
 		return err
 	}
 
+	if opts.file != "" {
+		file := opts.file
+		if filepath.IsAbs(file) {
+			if file, err = filepath.Rel(root, file); err != nil {
+				return err
+			}
+		}
+		refs = filterReferencesByFile(refs, filepath.Clean(file))
+		if !opts.count {
+			return outputFileReferences(refs, format, file)
+		}
+	}
+
+	if opts.count {
+		return outputReferenceCounts(keys, refs, format)
+	}
+
 	if format == "json" {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -49,3 +131,185 @@ func reportReferences(root, format string) error {
 	}
 	return nil
 }
+
+// singleKeyReferences is the references output for one key: its literal
+// references and the dynamic patterns that may also reach it.
+type singleKeyReferences struct {
+	Key        string               `json:"key"`
+	References []keyReference       `json:"references"`
+	Dynamic    []dynamicReportEntry `json:"dynamic"`
+}
+
+// outputKeyReferences prints the literal references to key, followed by
+// the dynamic patterns covering it, so a key with no literal reference
+// can still be seen to be used.
+func outputKeyReferences(key string, refs []keyReference, dynamics []dynamicKeyRef, format string) error {
+	if format == "json" {
+		result := singleKeyReferences{
+			Key:        key,
+			References: refs,
+			Dynamic:    make([]dynamicReportEntry, 0, len(dynamics)),
+		}
+		if result.References == nil {
+			result.References = []keyReference{}
+		}
+		for _, d := range dynamics {
+			result.Dynamic = append(result.Dynamic, dynamicReportEntry{
+				Pattern: d.Pattern,
+				Source:  fmt.Sprintf("%s:%d", d.Ref.File, d.Ref.Line),
+				Matches: []string{key},
+			})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	if len(refs) == 0 {
+		fmt.Printf("No literal references found for %s.\n", key)
+	} else {
+		fmt.Printf("%s:\n", key)
+		for _, loc := range refs {
+			fmt.Printf("  %s:%d\n", loc.File, loc.Line)
+		}
+	}
+	if len(dynamics) > 0 {
+		fmt.Printf("Covered by %d dynamic key patterns:\n", len(dynamics))
+		for _, d := range dynamics {
+			fmt.Printf("  %s  (%s:%d)\n", d.Pattern, d.Ref.File, d.Ref.Line)
+		}
+	}
+	return nil
+}
+
+// outputReferenceCounts prints how many times each en-us.yaml key is
+// referenced. Text output is sorted by count, highest first; keys without
+// references are omitted.
+func outputReferenceCounts(keys map[string]string, refs map[string][]keyReference, format string) error {
+	if format == "json" {
+		usage := make(map[string]keyUsage)
+		for k := range keys {
+			if len(refs[k]) > 0 {
+				usage[k] = keyUsage{Count: len(refs[k]), References: refs[k]}
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(usage)
+	}
+
+	var used []string
+	for _, k := range sortedKeys(keys) {
+		if len(refs[k]) > 0 {
+			used = append(used, k)
+		}
+	}
+	sort.SliceStable(used, func(i, j int) bool {
+		return len(refs[used[i]]) > len(refs[used[j]])
+	})
+	for _, k := range used {
+		fmt.Printf("%s: %d\n", k, len(refs[k]))
+	}
+	return nil
+}
+
+// singleUse is a key referenced from exactly one source location.
+type singleUse struct {
+	Key       string       `json:"key"`
+	Reference keyReference `json:"reference"`
+}
+
+// singleUseKeys returns the keys with exactly one literal reference, in
+// key order. Keys that a dynamic pattern may also reach are left out,
+// since their real number of uses is unknown.
+func singleUseKeys(refs map[string][]keyReference, dynamics []dynamicKeyRef, keys map[string]string) []singleUse {
+	var result []singleUse
+	for _, k := range sortedKeys(keys) {
+		if len(refs[k]) != 1 {
+			continue
+		}
+		dynamic := false
+		for _, d := range dynamics {
+			if d.Regex.MatchString(k) {
+				dynamic = true
+				break
+			}
+		}
+		if !dynamic {
+			result = append(result, singleUse{Key: k, Reference: refs[k][0]})
+		}
+	}
+	return result
+}
+
+// outputSingleUse prints single-use keys with their location.
+func outputSingleUse(keys []singleUse, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(keys)
+	}
+
+	if len(keys) == 0 {
+		fmt.Println("No single-use keys found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d single-use keys:\n", len(keys))
+	for _, s := range keys {
+		fmt.Printf("  %s  %s:%d\n", s.Key, s.Reference.File, s.Reference.Line)
+	}
+	return nil
+}
+
+// filterReferencesByFile keeps only the references located in file.
+// Keys with no remaining references are dropped.
+func filterReferencesByFile(refs map[string][]keyReference, file string) map[string][]keyReference {
+	filtered := make(map[string][]keyReference)
+	for k, locations := range refs {
+		for _, loc := range locations {
+			if loc.File == file {
+				filtered[k] = append(filtered[k], loc)
+			}
+		}
+	}
+	return filtered
+}
+
+// outputFileReferences prints the keys used by a single file with the line
+// numbers of each use: the transpose of the default references output.
+func outputFileReferences(refs map[string][]keyReference, format, file string) error {
+	lines := make(map[string][]int, len(refs))
+	for k, locations := range refs {
+		for _, loc := range locations {
+			lines[k] = append(lines[k], loc.Line)
+		}
+		sort.Ints(lines[k])
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(lines)
+	}
+
+	if len(lines) == 0 {
+		fmt.Printf("No keys referenced in %s.\n", file)
+		return nil
+	}
+
+	keys := make([]string, 0, len(lines))
+	for k := range lines {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Printf("Found %d keys referenced in %s:\n", len(keys), file)
+	for _, k := range keys {
+		nums := make([]string, len(lines[k]))
+		for i, n := range lines[k] {
+			nums[i] = strconv.Itoa(n)
+		}
+		fmt.Printf("  %s: %s\n", k, strings.Join(nums, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFilterReferencesByFile(t *testing.T) {
+	refs := map[string][]keyReference{
+		"nav.home":  {{File: "a.vue", Line: 3}, {File: "b.vue", Line: 7}},
+		"nav.about": {{File: "b.vue", Line: 9}},
+		"tray.quit": {{File: "a.vue", Line: 10}, {File: "a.vue", Line: 1}},
+	}
+	got := filterReferencesByFile(refs, "a.vue")
+	want := map[string][]keyReference{
+		"nav.home":  {{File: "a.vue", Line: 3}},
+		"tray.quit": {{File: "a.vue", Line: 10}, {File: "a.vue", Line: 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSingleUseKeys(t *testing.T) {
+	keys := map[string]string{
+		"nav.home":     "Home",
+		"nav.about":    "About",
+		"tray.quit":    "Quit",
+		"status.ready": "Ready",
+		"unused.key":   "Unused",
+	}
+	refs := map[string][]keyReference{
+		"nav.home":     {{File: "a.vue", Line: 3}, {File: "b.vue", Line: 7}},
+		"nav.about":    {{File: "b.vue", Line: 9}},
+		"tray.quit":    {{File: "a.vue", Line: 10}},
+		"status.ready": {{File: "c.vue", Line: 2}},
+	}
+	dynamics := []dynamicKeyRef{{Regex: regexp.MustCompile(`^status\.[^.]+$`)}}
+	got := singleUseKeys(refs, dynamics, keys)
+	want := []singleUse{
+		{Key: "nav.about", Reference: keyReference{File: "b.vue", Line: 9}},
+		{Key: "tray.quit", Reference: keyReference{File: "a.vue", Line: 10}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestOutputKeyReferences(t *testing.T) {
+	dynamics := []dynamicKeyRef{{
+		Pattern: "status.{}",
+		Regex:   regexp.MustCompile(`^status\.[^.]+$`),
+		Ref:     keyReference{File: "c.vue", Line: 4},
+	}}
+	out, err := captureStdout(t, func() error {
+		return outputKeyReferences("status.ready", []keyReference{{File: "a.vue", Line: 2}}, dynamicPatternsForKey(dynamics, "status.ready"), "text")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "status.ready:\n  a.vue:2\nCovered by 1 dynamic key patterns:\n  status.{}  (c.vue:4)\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, err = captureStdout(t, func() error {
+		return outputKeyReferences("nav.home", nil, dynamicPatternsForKey(dynamics, "nav.home"), "text")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "No literal references found for nav.home.\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestReportReferencesCount(t *testing.T) {
+	dir := writeCheckFixture(t)
+	src := "<h1>{{ t('nav.home') }}</h1>\n<p>{{ t('nav.about') }}</p>\n"
+	os.WriteFile(filepath.Join(dir, "pkg", "rancher-desktop", "pages", "About.vue"), []byte(src), 0644)
+
+	out, err := captureStdout(t, func() error {
+		return reportReferences(dir, "text", referencesOptions{count: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Most used first.
+	if want := "nav.home: 2\nnav.about: 1\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
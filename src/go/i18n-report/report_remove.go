@@ -5,8 +5,11 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -15,7 +18,12 @@ import (
 func runRemove(args []string) error {
 	fs := flag.NewFlagSet("remove", flag.ExitOnError)
 	stale := fs.Bool("stale", false, "Remove stale keys from all locale files (keys not in en-us.yaml)")
+	dryRun := fs.Bool("dry-run", false, "List the keys that would be removed from each file without writing")
+	backup := fs.Bool("backup", false, "Copy each file to <file>.bak before rewriting it, replacing any earlier backup")
+	quiet := fs.Bool("quiet", false, "Do not print the per-file summary lines on stderr")
+	sourceLocale := sourceLocaleFlag(fs)
 	fs.Parse(args)
+	opts := removeOptions{dryRun: *dryRun, backup: *backup, quiet: *quiet, sourceLocale: *sourceLocale}
 
 	root, err := repoRoot()
 	if err != nil {
@@ -23,46 +31,87 @@ func runRemove(args []string) error {
 	}
 
 	if *stale {
-		return removeStaleKeys(root)
+		return removeStaleKeys(root, opts)
 	}
 
-	// Read keys to remove from stdin.
-	keys, err := readKeysFromStdin()
+	// Read keys to remove from file arguments, or stdin if none are given.
+	var keys []string
+	source := "stdin"
+	if fs.NArg() > 0 {
+		keys, err = readKeysFromFiles(fs.Args())
+		source = strings.Join(fs.Args(), ", ")
+	} else {
+		keys, err = readKeys(os.Stdin)
+	}
 	if err != nil {
 		return err
 	}
 	if len(keys) == 0 {
-		return fmt.Errorf("no valid keys provided on stdin")
+		return fmt.Errorf("no valid keys provided on %s", source)
 	}
 
 	keySet := make(map[string]bool, len(keys))
 	for _, k := range keys {
 		keySet[k] = true
 	}
+	return removeKeysFromAll(root, keySet, opts)
+}
 
+// removeOptions controls how translation files are rewritten.
+type removeOptions struct {
+	dryRun       bool   // compute removals without writing
+	backup       bool   // copy each file to <file>.bak before rewriting it
+	quiet        bool   // omit the per-file summary lines
+	sourceLocale string // locale defining the keys for --stale; empty for en-us
+}
+
+// removeKeysFromAll removes the given keys from en-us.yaml and every
+// locale file, reporting per-file counts on stderr. With dryRun, files
+// are left untouched and the keys that would be removed are listed.
+func removeKeysFromAll(root string, keySet map[string]bool, opts removeOptions) error {
 	targets, err := findTranslationFiles(root)
 	if err != nil {
 		return err
 	}
 
 	for _, path := range targets {
-		removed, err := removeKeysFromFile(path, keySet)
+		removed, err := removeKeysFromFile(path, keySet, opts)
 		if err != nil {
 			return err
 		}
-		if removed > 0 {
+		if len(removed) > 0 {
 			relPath, _ := filepath.Rel(root, path)
-			fmt.Fprintf(os.Stderr, "Removed %d keys from %s\n", removed, relPath)
+			reportRemoved(relPath, "keys", removed, opts)
 		}
 	}
 
 	return nil
 }
 
-// removeStaleKeys removes keys from each non-en-us locale file that
-// do not exist in en-us.yaml.
-func removeStaleKeys(root string) error {
-	enPath := translationsPath(root, "en-us.yaml")
+// reportRemoved prints the result of removing keys from one file. In dry
+// run mode it lists each key that would be removed; otherwise it prints a
+// count, unless quiet.
+func reportRemoved(relPath, what string, removed []string, opts removeOptions) {
+	if !opts.dryRun {
+		if opts.quiet {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Removed %d %s from %s\n", len(removed), what, relPath)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Would remove %d %s from %s:\n", len(removed), what, relPath)
+	for _, k := range removed {
+		fmt.Fprintf(os.Stderr, "  %s\n", k)
+	}
+}
+
+// removeStaleKeys removes keys from each locale file other than the
+// source locale that do not exist in the source locale file.
+func removeStaleKeys(root string, opts removeOptions) error {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return err
+	}
 	enKeys, err := loadYAMLFlat(enPath)
 	if err != nil {
 		return err
@@ -74,7 +123,7 @@ func removeStaleKeys(root string) error {
 	}
 
 	for _, path := range targets {
-		if filepath.Base(path) == "en-us.yaml" {
+		if localeName(path) == sourceLocaleOrDefault(opts.sourceLocale) {
 			continue
 		}
 
@@ -94,23 +143,23 @@ func removeStaleKeys(root string) error {
 			continue
 		}
 
-		removed, err := removeKeysFromFile(path, staleKeys)
+		removed, err := removeKeysFromFile(path, staleKeys, opts)
 		if err != nil {
 			return err
 		}
 		relPath, _ := filepath.Rel(root, path)
-		fmt.Fprintf(os.Stderr, "Removed %d stale keys from %s\n", removed, relPath)
+		reportRemoved(relPath, "stale keys", removed, opts)
 	}
 
 	return nil
 }
 
-// readKeysFromStdin reads dotted translation keys from stdin, one per line.
+// readKeys reads dotted translation keys from r, one per line.
 // Lines that are not valid dotted keys are skipped, so the output of
 // `unused` or `stale` can be piped directly.
-func readKeysFromStdin() ([]string, error) {
+func readKeys(r io.Reader) ([]string, error) {
 	var keys []string
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		key := strings.TrimSpace(scanner.Text())
 		if isValidDottedKey(key) {
@@ -120,6 +169,25 @@ func readKeysFromStdin() ([]string, error) {
 	return keys, scanner.Err()
 }
 
+// readKeysFromFiles reads dotted translation keys from each file in turn,
+// filtering lines the same way as readKeys.
+func readKeysFromFiles(paths []string) ([]string, error) {
+	var keys []string
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		fileKeys, err := readKeys(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		keys = append(keys, fileKeys...)
+	}
+	return keys, nil
+}
+
 // findTranslationFiles returns paths to all YAML files in the translations
 // directory, excluding prompt and README files.
 func findTranslationFiles(root string) ([]string, error) {
@@ -130,55 +198,70 @@ func findTranslationFiles(root string) ([]string, error) {
 	}
 	var paths []string
 	for _, e := range entries {
-		if !e.IsDir() && strings.HasSuffix(e.Name(), ".yaml") {
+		if !e.IsDir() && slices.Contains(translationExts, filepath.Ext(e.Name())) {
 			paths = append(paths, filepath.Join(dir, e.Name()))
 		}
 	}
 	return paths, nil
 }
 
-// removeKeysFromFile removes the given dotted keys from a YAML file,
-// pruning empty parent nodes. Returns the number of keys removed.
-func removeKeysFromFile(path string, keys map[string]bool) (int, error) {
+// removeKeysFromFile removes the given dotted keys from a YAML or JSON
+// file, pruning empty parent nodes. JSON files are parsed as YAML and
+// written back as JSON. Returns the sorted keys that were removed.
+// With dryRun, the removal is computed but the file is not rewritten; with
+// backup, the original is saved to <path>.bak first.
+func removeKeysFromFile(path string, keys map[string]bool, opts removeOptions) ([]string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	var doc yaml.Node
 	if err := yaml.Unmarshal(data, &doc); err != nil {
-		return 0, fmt.Errorf("parsing %s: %w", path, err)
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
 	}
 
 	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
-		return 0, nil
+		return nil, nil
 	}
 	root := doc.Content[0]
 	if root.Kind != yaml.MappingNode {
-		return 0, nil
+		return nil, nil
 	}
 
-	removed := 0
+	var removed []string
 	for key := range keys {
 		if removeKeyFromNode(root, strings.Split(key, ".")) {
-			removed++
+			removed = append(removed, key)
 		}
 	}
+	sort.Strings(removed)
 
-	if removed == 0 {
-		return 0, nil
+	if len(removed) == 0 || opts.dryRun {
+		return removed, nil
 	}
 
 	var buf bytes.Buffer
-	enc := yaml.NewEncoder(&buf)
-	enc.SetIndent(2)
-	if err := enc.Encode(&doc); err != nil {
-		return 0, fmt.Errorf("encoding %s: %w", path, err)
+	if isJSONFile(path) {
+		var out strings.Builder
+		writeJSONNode(&out, &doc, 0)
+		buf.WriteString(out.String() + "\n")
+	} else {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", path, err)
+		}
+		enc.Close()
 	}
-	enc.Close()
 
+	if opts.backup {
+		if err := backupFile(path); err != nil {
+			return nil, err
+		}
+	}
 	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
-		return 0, fmt.Errorf("writing %s: %w", path, err)
+		return nil, fmt.Errorf("writing %s: %w", path, err)
 	}
 
 	return removed, nil
@@ -75,15 +75,15 @@ func TestRemoveKeyFromNode(t *testing.T) {
 			}
 
 			keys := map[string]bool{tc.key: true}
-			removed, err := removeKeysFromFile(path, keys)
+			removed, err := removeKeysFromFile(path, keys, removeOptions{})
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			if tc.removed && removed == 0 {
+			if tc.removed && len(removed) == 0 {
 				t.Error("expected key to be removed, but it was not")
 			}
-			if !tc.removed && removed > 0 {
+			if !tc.removed && len(removed) > 0 {
 				t.Error("expected no removal, but key was removed")
 			}
 
@@ -103,7 +103,7 @@ func TestRemoveKeyFromNode(t *testing.T) {
 				leaf := parts[len(parts)-1]
 				// Simple check: the leaf key should not appear with its
 				// original value.
-				if strings.Contains(got, leaf+":") && removed == 0 {
+				if strings.Contains(got, leaf+":") && len(removed) == 0 {
 					t.Errorf("key %q still present in output", tc.key)
 				}
 			}
@@ -120,12 +120,12 @@ func TestRemoveMultipleKeys(t *testing.T) {
 	}
 
 	keys := map[string]bool{"a": true, "c": true}
-	removed, err := removeKeysFromFile(path, keys)
+	removed, err := removeKeysFromFile(path, keys, removeOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if removed != 2 {
-		t.Errorf("removed %d keys, want 2", removed)
+	if len(removed) != 2 {
+		t.Errorf("removed %d keys, want 2", len(removed))
 	}
 
 	data, err := os.ReadFile(path)
@@ -159,12 +159,12 @@ func TestRemoveKeysFromFileNoChanges(t *testing.T) {
 	infoBefore, _ := os.Stat(path)
 
 	keys := map[string]bool{"nonexistent": true}
-	removed, err := removeKeysFromFile(path, keys)
+	removed, err := removeKeysFromFile(path, keys, removeOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if removed != 0 {
-		t.Errorf("removed %d keys, want 0", removed)
+	if len(removed) != 0 {
+		t.Errorf("removed %d keys, want 0", len(removed))
 	}
 
 	// File should not be rewritten when nothing was removed.
@@ -174,24 +174,67 @@ func TestRemoveKeysFromFileNoChanges(t *testing.T) {
 	}
 }
 
+func TestRemoveKeysFromFileBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.yaml")
+	os.WriteFile(path+".bak", []byte("old backup\n"), 0644)
+	original := "a: 1\nb: 2\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := removeKeysFromFile(path, map[string]bool{"a": true}, removeOptions{backup: true}); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := os.ReadFile(path + ".bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(backup) != original {
+		t.Errorf("backup = %q, want the original %q", backup, original)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "b: 2\n" {
+		t.Errorf("file = %q, want %q", data, "b: 2\n")
+	}
+}
+
+func TestRemoveKeysFromFileDryRun(t *testing.T) {
+	yaml := "a:\n  x: 1\n  y: 2\nb: 3\n"
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := map[string]bool{"b": true, "a.x": true, "nonexistent.key": true}
+	removed, err := removeKeysFromFile(path, keys, removeOptions{dryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(removed, ",") != "a.x,b" {
+		t.Errorf("removed = %v, want [a.x b]", removed)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != yaml {
+		t.Errorf("dry run rewrote the file:\n%s", data)
+	}
+}
+
 func TestReadKeysFiltersNonKeys(t *testing.T) {
-	// readKeysFromStdin reads from os.Stdin; test isValidDottedKey filtering
-	// directly since stdin is hard to mock.
-	lines := []string{
+	input := strings.Join([]string{
 		"action.refresh",
 		"Found 10 unused keys:",
 		"",
 		"nav.home.title",
 		"not-dotted",
 		"  whitespace.padded  ",
-	}
+	}, "\n")
 
-	var keys []string
-	for _, line := range lines {
-		key := strings.TrimSpace(line)
-		if isValidDottedKey(key) {
-			keys = append(keys, key)
-		}
+	keys, err := readKeys(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	want := []string{"action.refresh", "nav.home.title", "whitespace.padded"}
@@ -204,3 +247,69 @@ func TestReadKeysFiltersNonKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestReadKeysFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	second := filepath.Join(dir, "second.txt")
+	os.WriteFile(first, []byte("Found 2 unused keys:\n  a.one\n  a.two\n"), 0644)
+	os.WriteFile(second, []byte("b.three\n"), 0644)
+
+	keys, err := readKeysFromFiles([]string{first, second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(keys, ",") != "a.one,a.two,b.three" {
+		t.Errorf("got %v", keys)
+	}
+
+	if _, err := readKeysFromFiles([]string{filepath.Join(dir, "missing.txt")}); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestRemoveKeysFromAllQuiet(t *testing.T) {
+	dir := writeCheckFixture(t)
+	keys := map[string]bool{"nav.home": true}
+
+	out, err := captureStderr(t, func() error {
+		return removeKeysFromAll(dir, keys, removeOptions{quiet: true, dryRun: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Would remove 1 keys from") {
+		t.Errorf("--quiet should keep the dry-run listing, got %q", out)
+	}
+
+	out, err = captureStderr(t, func() error {
+		return removeKeysFromAll(dir, keys, removeOptions{quiet: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "" {
+		t.Errorf("expected no summary with --quiet, got %q", out)
+	}
+}
+
+func TestRemoveStaleKeysSourceLocale(t *testing.T) {
+	dir := writeCheckFixture(t)
+	enGB := translationsPath(dir, "en-gb.yaml")
+	os.WriteFile(enGB, []byte("nav:\n  home: Home\n  colour: Colour\n"), 0644)
+
+	// en-gb is the source, so its own keys are never stale, while
+	// en-us.yaml is an ordinary locale with nav.about left over.
+	if _, err := captureStderr(t, func() error {
+		return removeStaleKeys(dir, removeOptions{quiet: true, sourceLocale: "en-gb"})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := loadYAMLFlat(enGB); len(got) != 2 {
+		t.Errorf("en-gb.yaml keys = %v, want both kept", got)
+	}
+	got, _ := loadYAMLFlat(translationsPath(dir, "en-us.yaml"))
+	if _, found := got["nav.about"]; found || len(got) != 1 {
+		t.Errorf("en-us.yaml keys = %v, want only nav.home", got)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// scaffoldComment marks each scaffolded value as still needing translation.
+const scaffoldComment = "# TODO translate"
+
+func runScaffold(args []string) error {
+	fs := flag.NewFlagSet("scaffold", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code of the new file (required)")
+	force := fs.Bool("force", false, "Overwrite the locale file if it already exists")
+	fs.Parse(args)
+
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportScaffold(root, *locale, *force)
+}
+
+// reportScaffold writes a new locale file containing every en-us.yaml key
+// with its English value, in en-us.yaml order, each preceded by any
+// English annotation and a "# TODO translate" comment. An existing file is
+// only replaced when force is set.
+func reportScaffold(root, locale string, force bool) error {
+	enPath := translationsPath(root, "en-us.yaml")
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		if _, err := os.Stat(localePath); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", localePath)
+		}
+	}
+
+	enEntries, err := loadYAMLWithComments(enPath)
+	if err != nil {
+		return err
+	}
+	order, err := loadYAMLKeyOrder(enPath)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]mergeEntry, 0, len(enEntries))
+	for _, e := range enEntries {
+		if e.comment != "" {
+			e.comment = strings.TrimRight(e.comment, "\n") + "\n" + scaffoldComment
+		} else {
+			e.comment = scaffoldComment
+		}
+		entries = append(entries, e)
+	}
+
+	var buf strings.Builder
+	writeNestedYAML(&buf, entries, yamlWriteOptions{order: order})
+	if err := os.WriteFile(localePath, []byte(buf.String()), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", localePath, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %d keys to %s\n", len(entries), localePath)
+	return nil
+}
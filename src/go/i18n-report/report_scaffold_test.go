@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportScaffold(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	en := "nav:\n  # @context Main navigation\n  home: Home\n  about: About\n"
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte(en), 0644)
+
+	if err := reportScaffold(dir, "pt-br", false); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(transDir, "pt-br.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "nav:\n" +
+		"  # @context Main navigation\n" +
+		"  # TODO translate\n" +
+		"  home: Home\n" +
+		"  # TODO translate\n" +
+		"  about: About\n"
+	if string(data) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", data, want)
+	}
+
+	if err := reportScaffold(dir, "pt-br", false); err == nil {
+		t.Error("expected an error for an existing file without --force")
+	}
+	if err := reportScaffold(dir, "pt-br", true); err != nil {
+		t.Errorf("--force: %v", err)
+	}
+}
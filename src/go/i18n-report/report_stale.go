@@ -8,9 +8,15 @@ import (
 func runStale(args []string) error {
 	fs := flag.NewFlagSet("stale", flag.ExitOnError)
 	locale := fs.String("locale", "", "Target locale code (required)")
-	format := fs.String("format", "text", "Output format: text, json")
+	format := fs.String("format", "text", "Output format: text, json, yaml")
+	rich := fs.Bool("rich", false, "In JSON output, include the en-us and locale values of each key")
+	source := sourceLocaleFlag(fs)
 	fs.Parse(args)
 
+	if err := checkFormat(*format, "text", "json", "yaml"); err != nil {
+		return err
+	}
+
 	if *locale == "" {
 		return fmt.Errorf("--locale is required")
 	}
@@ -19,12 +25,24 @@ func runStale(args []string) error {
 	if err != nil {
 		return err
 	}
-	return reportStale(root, *locale, *format)
+	return reportStale(root, *locale, *format, staleOptions{rich: *rich, sourceLocale: *source})
 }
 
-func reportStale(root, locale, format string) error {
-	enPath := translationsPath(root, "en-us.yaml")
-	localePath := translationsPath(root, locale+".yaml")
+// staleOptions holds the optional behaviors of the stale subcommand.
+type staleOptions struct {
+	rich         bool   // include values in JSON output
+	sourceLocale string // locale a key must exist in to be current; empty for en-us
+}
+
+func reportStale(root, locale, format string, opts staleOptions) error {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
 
 	enKeys, err := loadYAMLFlat(enPath)
 	if err != nil {
@@ -42,5 +60,8 @@ func reportStale(root, locale, format string) error {
 		}
 	}
 
+	if opts.rich && format == "json" {
+		return outputKeyValues(keyValues(stale, enKeys, localeKeys))
+	}
 	return outputStrings(stale, format, "stale keys in "+locale)
 }
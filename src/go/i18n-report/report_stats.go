@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	scanOpts := scanFlags(fs)
+	fs.Parse(args)
+
+	scan, err := scanOpts()
+	if err != nil {
+		return err
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportStats(root, *format, scan)
+}
+
+// keyStats summarizes the key inventory.
+type keyStats struct {
+	Keys            int           `json:"keys"`
+	Referenced      int           `json:"referenced"`
+	DynamicOnly     int           `json:"dynamicOnly"`
+	Unused          int           `json:"unused"`
+	DynamicPatterns int           `json:"dynamicPatterns"`
+	Locales         []localeStats `json:"locales"`
+}
+
+// localeStats is the completeness of one locale against en-us.yaml.
+type localeStats struct {
+	Locale     string  `json:"locale"`
+	Translated int     `json:"translated"`
+	Missing    int     `json:"missing"`
+	Stale      int     `json:"stale"`
+	Percent    float64 `json:"percent"`
+}
+
+// reportStats prints aggregate counts for en-us.yaml keys, their use in
+// source, and the completeness of each locale, scanning the tree once.
+func reportStats(root, format string, opts scanOptions) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	refs, dynamics, err := scanFiles(root, enKeys, opts)
+	if err != nil {
+		return err
+	}
+
+	stats := keyStats{Keys: len(enKeys)}
+	literal := make(map[string]bool)
+	for k := range enKeys {
+		if _, found := refs[k]; found {
+			literal[k] = true
+		}
+	}
+	patterns := make(map[string]bool)
+	for _, d := range dynamics {
+		patterns[d.Pattern] = true
+	}
+	stats.DynamicPatterns = len(patterns)
+
+	resolveKeyReferences(refs, dynamics, enKeys, opts)
+	for k := range enKeys {
+		if _, found := refs[k]; !found {
+			stats.Unused++
+			continue
+		}
+		stats.Referenced++
+		if !literal[k] {
+			stats.DynamicOnly++
+		}
+	}
+
+	paths, err := findTranslationFiles(root)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		locale := localeName(path)
+		if locale == "en-us" {
+			continue
+		}
+		localeKeys, err := loadYAMLFlat(path)
+		if err != nil {
+			return err
+		}
+		stats.Locales = append(stats.Locales, computeLocaleStats(locale, enKeys, localeKeys))
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+
+	fmt.Printf("  %-22s %6d\n", "en-us keys:", stats.Keys)
+	fmt.Printf("  %-22s %6d  (%d only via dynamic patterns)\n", "referenced:", stats.Referenced, stats.DynamicOnly)
+	fmt.Printf("  %-22s %6d\n", "unused:", stats.Unused)
+	fmt.Printf("  %-22s %6d\n", "dynamic patterns:", stats.DynamicPatterns)
+	if len(stats.Locales) > 0 {
+		fmt.Println()
+		for _, l := range stats.Locales {
+			fmt.Printf("  %-22s %6d  %5.1f%%  (%d missing, %d stale)\n", l.Locale+":", l.Translated, l.Percent, l.Missing, l.Stale)
+		}
+	}
+	return nil
+}
+
+// computeLocaleStats counts the en-us keys a locale translates, lacks,
+// and the keys it defines that en-us.yaml no longer has.
+func computeLocaleStats(locale string, enKeys, localeKeys map[string]string) localeStats {
+	s := localeStats{Locale: locale}
+	for k := range enKeys {
+		if _, found := localeKeys[k]; found {
+			s.Translated++
+		} else {
+			s.Missing++
+		}
+	}
+	for k := range localeKeys {
+		if _, found := enKeys[k]; !found {
+			s.Stale++
+		}
+	}
+	if len(enKeys) > 0 {
+		s.Percent = 100 * float64(s.Translated) / float64(len(enKeys))
+	}
+	return s
+}
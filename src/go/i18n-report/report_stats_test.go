@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeLocaleStats(t *testing.T) {
+	enKeys := map[string]string{"a.one": "One", "a.two": "Two", "a.three": "Three", "a.four": "Four"}
+	localeKeys := map[string]string{"a.one": "Eins", "a.two": "Zwei", "a.old": "Alt"}
+
+	got := computeLocaleStats("de", enKeys, localeKeys)
+	want := localeStats{Locale: "de", Translated: 2, Missing: 2, Stale: 1, Percent: 50}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestReportStats(t *testing.T) {
+	dir := writeCheckFixture(t)
+	src := "const s = t(`nav.${ page }`);\n"
+	os.WriteFile(filepath.Join(dir, "pkg", "rancher-desktop", "pages", "Nav.vue"), []byte(src), 0644)
+
+	out, err := captureStdout(t, func() error {
+		return reportStats(dir, "json", scanOptions{})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got keyStats
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	// nav.home is used literally; nav.about only through nav.{}.
+	if got.Keys != 2 || got.Referenced != 2 || got.DynamicOnly != 1 || got.Unused != 0 || got.DynamicPatterns != 1 {
+		t.Errorf("got %+v", got)
+	}
+	if len(got.Locales) != 1 || got.Locales[0].Locale != "de" || got.Locales[0].Translated != 1 {
+		t.Errorf("locales = %+v", got.Locales)
+	}
+}
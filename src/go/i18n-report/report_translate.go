@@ -5,6 +5,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 )
 
 func runTranslate(args []string) error {
@@ -13,25 +15,59 @@ func runTranslate(args []string) error {
 	format := fs.String("format", "text", "Output format: text, json")
 	batch := fs.Int("batch", 0, "Batch number (1-indexed); requires --batches")
 	batches := fs.Int("batches", 0, "Total number of batches")
+	batchBy := fs.String("batch-by", "index", "Batch slicing: index (even split of sorted keys), group (whole top-level groups)")
+	context := fs.Int("context", 0, "Include up to N sibling en-us.yaml values as # comments before each key")
+	source := sourceLocaleFlag(fs)
 	fs.Parse(args)
 
+	if err := checkFormat(*format, "text", "json"); err != nil {
+		return err
+	}
+
 	if *locale == "" {
 		return fmt.Errorf("--locale is required")
 	}
+	if *batchBy != "index" && *batchBy != "group" {
+		return fmt.Errorf("--batch-by must be index or group, got %q", *batchBy)
+	}
+	if *context < 0 {
+		return fmt.Errorf("--context must not be negative")
+	}
 
 	root, err := repoRoot()
 	if err != nil {
 		return err
 	}
-	return reportTranslate(root, *locale, *format, *batch, *batches)
+	return reportTranslate(root, *locale, *format, translateOptions{
+		batch:        *batch,
+		batches:      *batches,
+		batchBy:      *batchBy,
+		context:      *context,
+		sourceLocale: *source,
+	})
+}
+
+// translateOptions holds the optional behaviors of the translate subcommand.
+type translateOptions struct {
+	batch        int    // 1-indexed batch to output; requires batches
+	batches      int    // total number of batches, 0 for no slicing
+	batchBy      string // "index" or "group"
+	context      int    // sibling values to include per key, 0 for none
+	sourceLocale string // locale providing the values to translate; empty for en-us
 }
 
 // reportTranslate outputs key=value pairs for keys in en-us.yaml that are
 // missing from a locale file. Annotations (@context, @meaning, @no-translate)
 // from en-us.yaml are included so translators have context.
-func reportTranslate(root, locale, format string, batch, batches int) error {
-	enPath := translationsPath(root, "en-us.yaml")
-	localePath := translationsPath(root, locale+".yaml")
+func reportTranslate(root, locale, format string, opts translateOptions) error {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
 
 	enEntries, err := loadYAMLWithComments(enPath)
 	if err != nil {
@@ -49,33 +85,50 @@ func reportTranslate(root, locale, format string, batch, batches int) error {
 	}
 
 	type kv struct {
-		Key     string `json:"key"`
-		Value   string `json:"value"`
-		Comment string `json:"comment,omitempty"`
+		Key     string   `json:"key"`
+		Value   string   `json:"value"`
+		Comment string   `json:"comment,omitempty"`
+		Context []string `json:"context,omitempty"`
 	}
+	sorted := sortedKeys(enKeyMap)
 	var pairs []kv
-	for _, k := range sortedKeys(enKeyMap) {
+	for _, k := range sorted {
 		if _, found := localeKeys[k]; !found {
-			pairs = append(pairs, kv{k, enEntries[k].value, enEntries[k].comment})
+			pairs = append(pairs, kv{k, enEntries[k].value, enEntries[k].comment, siblingContext(sorted, enKeyMap, k, opts.context)})
 		}
 	}
 
 	// Apply batch slicing if requested.
-	if batches > 0 {
-		if batch < 1 || batch > batches {
-			return fmt.Errorf("--batch must be between 1 and %d", batches)
+	if opts.batches > 0 {
+		if opts.batch < 1 || opts.batch > opts.batches {
+			return fmt.Errorf("--batch must be between 1 and %d", opts.batches)
 		}
-		total := len(pairs)
-		size := (total + batches - 1) / batches
-		start := (batch - 1) * size
-		end := start + size
-		if start > total {
-			start = total
+		if opts.batchBy == "group" {
+			keys := make([]string, len(pairs))
+			for i, p := range pairs {
+				keys[i] = p.Key
+			}
+			assigned := assignGroupBatches(keys, opts.batches)
+			var selected []kv
+			for _, p := range pairs {
+				if assigned[topLevelGroup(p.Key)] == opts.batch-1 {
+					selected = append(selected, p)
+				}
+			}
+			pairs = selected
+		} else {
+			total := len(pairs)
+			size := (total + opts.batches - 1) / opts.batches
+			start := (opts.batch - 1) * size
+			end := start + size
+			if start > total {
+				start = total
+			}
+			if end > total {
+				end = total
+			}
+			pairs = pairs[start:end]
 		}
-		if end > total {
-			end = total
-		}
-		pairs = pairs[start:end]
 	}
 
 	if format == "json" {
@@ -90,11 +143,14 @@ func reportTranslate(root, locale, format string, batch, batches int) error {
 	}
 
 	label := fmt.Sprintf("Found %d keys missing from %s", len(pairs), locale)
-	if batches > 0 {
-		label += fmt.Sprintf(" (batch %d of %d)", batch, batches)
+	if opts.batches > 0 {
+		label += fmt.Sprintf(" (batch %d of %d)", opts.batch, opts.batches)
 	}
 	fmt.Printf("%s:\n\n", label)
 	for _, p := range pairs {
+		for _, c := range p.Context {
+			fmt.Printf("# context: %s\n", c)
+		}
 		if p.Comment != "" {
 			fmt.Println(p.Comment)
 		}
@@ -102,3 +158,68 @@ func reportTranslate(root, locale, format string, batch, batches int) error {
 	}
 	return nil
 }
+
+// siblingContext returns up to limit "key=value" strings for the en-us.yaml
+// keys sharing key's parent, in sorted order. Newlines in values are
+// escaped so each entry stays on one comment line. Keys must be sorted.
+func siblingContext(keys []string, values map[string]string, key string, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+	parent := ""
+	if idx := strings.LastIndex(key, "."); idx >= 0 {
+		parent = key[:idx+1]
+	}
+	var context []string
+	for _, k := range keys {
+		if len(context) == limit {
+			break
+		}
+		if k == key || !strings.HasPrefix(k, parent) || strings.Contains(k[len(parent):], ".") {
+			continue
+		}
+		context = append(context, k+"="+strings.ReplaceAll(values[k], "\n", `\n`))
+	}
+	return context
+}
+
+// topLevelGroup returns the first segment of a dotted key.
+func topLevelGroup(key string) string {
+	group, _, _ := strings.Cut(key, ".")
+	return group
+}
+
+// assignGroupBatches distributes the top-level groups of keys across
+// batches so that no group is split, returning the 0-indexed batch of each
+// group. Groups are placed largest first into the batch holding the fewest
+// keys so far; ties go to the lower batch and the group sorting first.
+func assignGroupBatches(keys []string, batches int) map[string]int {
+	counts := make(map[string]int)
+	for _, k := range keys {
+		counts[topLevelGroup(k)]++
+	}
+	groups := make([]string, 0, len(counts))
+	for g := range counts {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] < groups[j]
+	})
+
+	sizes := make([]int, batches)
+	assigned := make(map[string]int, len(groups))
+	for _, g := range groups {
+		smallest := 0
+		for b := 1; b < batches; b++ {
+			if sizes[b] < sizes[smallest] {
+				smallest = b
+			}
+		}
+		assigned[g] = smallest
+		sizes[smallest] += counts[g]
+	}
+	return assigned
+}
@@ -34,7 +34,7 @@ locale:
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := reportTranslate(dir, "de", "text", 0, 0)
+	err := reportTranslate(dir, "de", "text", translateOptions{})
 	w.Close()
 	os.Stdout = oldStdout
 
@@ -78,7 +78,7 @@ func TestReportTranslateJSON(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := reportTranslate(dir, "de", "json", 0, 0)
+	err := reportTranslate(dir, "de", "json", translateOptions{})
 	w.Close()
 	os.Stdout = oldStdout
 
@@ -97,3 +97,76 @@ func TestReportTranslateJSON(t *testing.T) {
 		t.Errorf("JSON output missing annotation:\n%s", output)
 	}
 }
+
+func TestReportTranslateContext(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+
+	enUS := `tray:
+  # @reason Shown next to the runtime name
+  containerEngine: "Container engine: {name}"
+  preferences: Preferences
+  quit: Quit
+  nested:
+    deep: Deep
+`
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte(enUS), 0644)
+	de := `tray:
+  preferences: Einstellungen
+  quit: Beenden
+  nested:
+    deep: Tief
+`
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte(de), 0644)
+
+	output, err := captureStdout(t, func() error {
+		return reportTranslate(dir, "de", "text", translateOptions{context: 1})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# context: tray.preferences=Preferences\n" +
+		"# @reason Shown next to the runtime name\n" +
+		"tray.containerEngine=Container engine: {name}\n"
+	if !strings.Contains(output, want) {
+		t.Errorf("output missing context block %q:\n%s", want, output)
+	}
+
+	// The context lines must not turn into entries on the way back.
+	entries, err := parseMergeInput(strings.NewReader(output), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].key != "tray.containerEngine" {
+		t.Fatalf("parseMergeInput returned %+v", entries)
+	}
+	if entries[0].comment != "# @reason Shown next to the runtime name" {
+		t.Errorf("comment = %q", entries[0].comment)
+	}
+}
+
+func TestAssignGroupBatches(t *testing.T) {
+	keys := []string{
+		"containerEngine.a", "containerEngine.b", "containerEngine.c", "containerEngine.d",
+		"images.a", "images.b", "images.c",
+		"tray.a", "tray.b",
+		"nav.a",
+	}
+	got := assignGroupBatches(keys, 2)
+	want := map[string]int{
+		"containerEngine": 0,
+		"images":          1,
+		"tray":            1,
+		"nav":             0,
+	}
+	for g, b := range want {
+		if got[g] != b {
+			t.Errorf("group %s assigned to batch %d, want %d", g, got[g], b)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d groups, want %d", len(got), len(want))
+	}
+}
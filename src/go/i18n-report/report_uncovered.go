@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+)
+
+func runUncovered(args []string) error {
+	fs := flag.NewFlagSet("uncovered", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json, yaml")
+	fs.Parse(args)
+
+	if err := checkFormat(*format, "text", "json", "yaml"); err != nil {
+		return err
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportUncovered(root, *format)
+}
+
+// reportUncovered lists the en-us.yaml keys that no other locale defines:
+// keys that slipped through translation entirely, as opposed to the
+// per-locale gaps reported by missing.
+func reportUncovered(root, format string) error {
+	enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+
+	paths, err := findTranslationFiles(root)
+	if err != nil {
+		return err
+	}
+	var locales []map[string]string
+	for _, path := range paths {
+		if localeName(path) == "en-us" {
+			continue
+		}
+		keys, err := loadYAMLFlat(path)
+		if err != nil {
+			return err
+		}
+		locales = append(locales, keys)
+	}
+
+	return outputStrings(uncoveredKeys(enKeys, locales), format, "uncovered keys")
+}
+
+// uncoveredKeys returns the keys of enKeys absent from every one of
+// locales, sorted.
+func uncoveredKeys(enKeys map[string]string, locales []map[string]string) []string {
+	var uncovered []string
+	for _, k := range sortedKeys(enKeys) {
+		covered := false
+		for _, keys := range locales {
+			if _, found := keys[k]; found {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, k)
+		}
+	}
+	return uncovered
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUncoveredKeys(t *testing.T) {
+	enKeys := map[string]string{
+		"nav.home":  "Home",
+		"nav.about": "About",
+		"nav.help":  "Help",
+		"tray.quit": "Quit",
+	}
+	locales := []map[string]string{
+		{"nav.home": "Startseite", "nav.old": "Alt"},
+		{"nav.home": "主页", "nav.about": "关于"},
+	}
+	got := uncoveredKeys(enKeys, locales)
+	want := []string{"nav.help", "tray.quit"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
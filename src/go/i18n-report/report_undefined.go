@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// maxSuggestionDistance is the largest edit distance at which an existing
+// key is offered as a suggestion for an undefined one. Shorter keys get a
+// tighter limit; see suggestionLimit.
+const maxSuggestionDistance = 3
+
+func runUndefined(args []string) error {
+	fs := flag.NewFlagSet("undefined", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportUndefined(root, *format)
+}
+
+// undefinedKey is a key referenced in source that en-us.yaml does not
+// define, with the closest existing key if one is near enough.
+type undefinedKey struct {
+	Key        string         `json:"key"`
+	References []keyReference `json:"references"`
+	Suggestion string         `json:"suggestion,omitempty"`
+}
+
+// reportUndefined lists the literal keys that source files pass to t()
+// (or set as titleKey, label-key, v-t, ...) but en-us.yaml does not
+// define. These render as the raw key at runtime, usually because of a
+// typo, so each one is paired with the closest defined key.
+func reportUndefined(root, format string) error {
+	keys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+	if err != nil {
+		return err
+	}
+	refs, _, err := scanFiles(root, keys, scanOptions{})
+	if err != nil {
+		return err
+	}
+
+	undefined := findUndefinedKeys(refs, keys)
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(undefined)
+	}
+
+	if len(undefined) == 0 {
+		fmt.Println("No undefined keys found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d undefined keys:\n", len(undefined))
+	for _, u := range undefined {
+		fmt.Printf("  %s\n", u.Key)
+		for _, r := range u.References {
+			fmt.Printf("    %s:%d\n", r.File, r.Line)
+		}
+		if u.Suggestion != "" {
+			fmt.Printf("    did you mean %s?\n", u.Suggestion)
+		}
+	}
+	return nil
+}
+
+// findUndefinedKeys returns the referenced dotted keys missing from keys,
+// sorted, each with a suggestion from suggestKey.
+func findUndefinedKeys(refs map[string][]keyReference, keys map[string]string) []undefinedKey {
+	byPrefix := make(map[string][]string)
+	for _, k := range sortedKeys(keys) {
+		byPrefix[topLevelGroup(k)] = append(byPrefix[topLevelGroup(k)], k)
+	}
+
+	var names []string
+	for k := range refs {
+		if _, defined := keys[k]; !defined && isValidDottedKey(k) {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	var undefined []undefinedKey
+	for _, k := range names {
+		undefined = append(undefined, undefinedKey{
+			Key:        k,
+			References: refs[k],
+			Suggestion: suggestKey(k, byPrefix),
+		})
+	}
+	return undefined
+}
+
+// suggestKey returns the defined key closest to key by edit distance, or
+// "" if none is within suggestionLimit. To stay cheap on large key
+// sets, only keys sharing key's first segment are compared, plus those
+// under top-level groups within the distance of that segment, which
+// catches a typo in the first segment itself. Ties go to the key sorting
+// first.
+func suggestKey(key string, byPrefix map[string][]string) string {
+	first := topLevelGroup(key)
+	limit := suggestionLimit(key)
+	best, bestDist := "", limit+1
+	for prefix, candidates := range byPrefix {
+		if prefix != first && levenshtein(prefix, first, limit) > limit {
+			continue
+		}
+		for _, c := range candidates {
+			d := levenshtein(key, c, bestDist)
+			if d < bestDist || (d == bestDist && d <= limit && c < best) {
+				best, bestDist = c, d
+			}
+		}
+	}
+	return best
+}
+
+// suggestionLimit returns the largest edit distance accepted for a
+// suggestion: one edit per five characters of key, at least one and at
+// most maxSuggestionDistance. This keeps "generic.and" from suggesting
+// "generic.ok".
+func suggestionLimit(key string) int {
+	return min(maxSuggestionDistance, max(1, len([]rune(key))/5))
+}
+
+// levenshtein returns the edit distance between a and b, or limit+1 as
+// soon as the distance is known to exceed limit.
+func levenshtein(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"action.refresh", "action.refresh", 3, 0},
+		{"action.refesh", "action.refresh", 3, 1},
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 2, 3},
+		{"short", "a much longer string", 3, 4},
+		{"", "abc", 5, 3},
+	}
+	for _, tc := range tests {
+		if got := levenshtein(tc.a, tc.b, tc.limit); got != tc.want {
+			t.Errorf("levenshtein(%q, %q, %d) = %d, want %d", tc.a, tc.b, tc.limit, got, tc.want)
+		}
+	}
+}
+
+func TestFindUndefinedKeys(t *testing.T) {
+	keys := map[string]string{
+		"action.refresh":        "Refresh",
+		"action.remove":         "Remove",
+		"generic.ok":            "OK",
+		"containerEngine.label": "Container Engine",
+		"nav.home":              "Home",
+	}
+	ref := keyReference{File: "a.vue", Line: 3}
+	refs := map[string][]keyReference{
+		"action.refesh":         {ref},
+		"containrEngine.label":  {ref},
+		"generic.and":           {ref},
+		"nav.home":              {ref},
+		"totally.unrelated.key": {ref},
+		"id":                    {ref},
+	}
+
+	got := findUndefinedKeys(refs, keys)
+	want := []undefinedKey{
+		{Key: "action.refesh", References: []keyReference{ref}, Suggestion: "action.refresh"},
+		{Key: "containrEngine.label", References: []keyReference{ref}, Suggestion: "containerEngine.label"},
+		{Key: "generic.and", References: []keyReference{ref}},
+		{Key: "totally.unrelated.key", References: []keyReference{ref}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// t(, $t(, this.t( (or the tc( pluralization forms) followed by
+	// something other than a string or template literal, or an
+	// immediately closing parenthesis.
+	variableCallPattern = regexp.MustCompile(`(?:^|[^a-zA-Z])tc?\(\s*[^'"\x60\s)]`)
+	// A typed parameter list, as in the definition of t itself:
+	// t(key: string, ...).
+	paramDeclPattern = regexp.MustCompile(`^\w+\??\s*:\s*\w`)
+)
+
+func runUnresolved(args []string) error {
+	fs := flag.NewFlagSet("unresolved", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	exclude := excludeFlag(fs)
+	fs.Parse(args)
+
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportUnresolved(root, *format, *exclude)
+}
+
+// unresolvedCall is a t() call whose key the scanner cannot determine.
+type unresolvedCall struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Argument string `json:"argument"`
+}
+
+// reportUnresolved lists t() and $t() calls whose first argument is an
+// expression rather than a string or template literal. Keys passed this
+// way are invisible to the scanner and may be reported as unused, so each
+// call site needs a human to check where its key comes from.
+func reportUnresolved(root, format string, exclude []string) error {
+	files, err := keySourceFiles(root, exclude)
+	if err != nil {
+		return err
+	}
+
+	var calls []unresolvedCall
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		relPath, _ := filepath.Rel(root, file)
+		calls = append(calls, findUnresolvedCalls(relPath, string(data))...)
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(calls)
+	}
+
+	if len(calls) == 0 {
+		fmt.Println("No unresolved t() calls found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d unresolved t() calls:\n", len(calls))
+	for _, c := range calls {
+		fmt.Printf("  %s:%d: t(%s)\n", c.File, c.Line, c.Argument)
+	}
+	return nil
+}
+
+// findUnresolvedCalls returns the t() calls in one file whose first
+// argument is not a literal. Definitions of t with typed parameters and
+// t(constant + '.suffix') calls the scanner resolves are skipped.
+func findUnresolvedCalls(relPath, src string) []unresolvedCall {
+	consts := stringConstants(src)
+	var calls []unresolvedCall
+	for i, line := range strings.Split(src, "\n") {
+		resolved := make(map[string]bool)
+		for _, m := range constConcatPattern.FindAllStringSubmatch(line, -1) {
+			if _, ok := consts[m[1]]; ok {
+				resolved[m[1]] = true
+			}
+		}
+		for _, loc := range variableCallPattern.FindAllStringIndex(line, -1) {
+			arg := firstArgument(line[loc[1]-1:])
+			if paramDeclPattern.MatchString(arg) {
+				continue
+			}
+			if name, _, ok := strings.Cut(arg, "+"); ok && resolved[strings.TrimSpace(name)] {
+				continue
+			}
+			calls = append(calls, unresolvedCall{File: relPath, Line: i + 1, Argument: arg})
+		}
+	}
+	return calls
+}
+
+// firstArgument returns the text of a call's first argument, starting at
+// s and ending at the first comma or closing parenthesis outside nested
+// brackets and quotes, or at the end of the line.
+func firstArgument(s string) string {
+	depth := 0
+	var quote rune
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			if depth == 0 {
+				return strings.TrimSpace(s[:i])
+			}
+			depth--
+		case r == ',' && depth == 0:
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindUnresolvedCalls(t *testing.T) {
+	src := `const baseKey = 'containerEngine.options';
+export default {
+  computed: {
+    title() { return this.t(this.titleKey); },
+    label() { return this.t(baseKey + '.label'); },
+    other() { return this.t(unknown + '.label'); },
+    picked() { return this.t(flag ? 'a.b' : 'a.c', { n: 1 }); },
+    nested() { return t(keys[index(1)], args); },
+    literal() { return t('nav.home') + t(` + "`nav.${x}`" + `); },
+    empty() { return t(); },
+  },
+};
+function t(key: string, args?: Record<string, any>) {}
+`
+	got := findUnresolvedCalls("a.vue", src)
+	want := []unresolvedCall{
+		{File: "a.vue", Line: 4, Argument: "this.titleKey"},
+		{File: "a.vue", Line: 6, Argument: "unknown + '.label'"},
+		{File: "a.vue", Line: 7, Argument: "flag ? 'a.b' : 'a.c'"},
+		{File: "a.vue", Line: 8, Argument: "keys[index(1)]"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
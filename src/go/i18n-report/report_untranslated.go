@@ -7,16 +7,42 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 // untranslatedHit records a hardcoded string found in a source file.
 type untranslatedHit struct {
-	File    string `json:"file"`
-	Line    int    `json:"line"`
-	Context string `json:"context"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Context    string `json:"context"`
+	Value      string `json:"value"`                // the matched string itself
+	Confidence int    `json:"confidence"`           // 1-100; see the confidence* constants
+	Suggestion string `json:"suggestion,omitempty"` // proposed key, with --suggest
 }
 
+// Confidence scores for untranslated hits, by the heuristic that matched.
+// Structural matches (a string literal in a known user-facing slot) score
+// higher than guesses based on text shape.
+const (
+	confidenceBoundLiteral = 90 // :label="'Text'"
+	confidenceMenuLabel    = 90 // label: 'Text' in an Electron menu template
+	confidenceAttribute    = 80 // label="Text" and similar attributes
+	confidenceDialogCall   = 80 // showErrorBox('Text', ...) and showMessageBox
+	confidenceDialog       = 70 // title/message/detail: 'Text'
+	confidencePropDefault  = 70 // props: { label: { default: 'Text' } }
+	confidenceErrorPush    = 70 // errors.push('Text')
+	confidenceInlineText   = 60 // <tag>Text</tag> on one line
+	confidenceNewError     = 50 // new Error('Some text')
+	confidenceBareText     = 40 // bare text line between tags
+	confidenceErrorFormat  = 30 // new Error(`Text ${ x }`); advisory only
+)
+
+// lowConfidence is the score below which text output marks a hit as a
+// guess. Higher scores are not printed; JSON output carries them all.
+const lowConfidence = 50
+
 // Patterns for detecting hardcoded English strings in Vue/TS files.
 var (
 	// Attributes that should use t() instead of hardcoded strings.
@@ -32,34 +58,123 @@ var (
 	bareTextPattern = regexp.MustCompile(`^[A-Z][a-zA-Z]{2,}(?: [a-zA-Z]+)*$`)
 	// Bound string literal attributes, e.g. :label="'Include Kubernetes services'".
 	boundLiteralPattern = regexp.MustCompile(`:(label|placeholder)="'([^']{3,})'"`)
-	// Validation error messages pushed to an errors array.
-	errorPushPattern = regexp.MustCompile(`errors\.push\(\s*['"\x60]`)
+	// Validation error messages pushed to an errors array; captures the
+	// message up to its closing quote.
+	errorPushPattern = regexp.MustCompile(`errors\.push\(\s*['"\x60]([^'"\x60]*)`)
+	// Files that build Electron menus from templates.
+	menuTemplateHint = regexp.MustCompile(`submenu\s*:|buildFromTemplate`)
+	// Suppression directives: "i18n-report-ignore" on its own ignores the
+	// line it is on; "-next N" ignores the following N lines; "-start" and
+	// "-end" bracket a block. See ignoredLines.
+	ignoreDirective = regexp.MustCompile(`i18n-report-ignore(?:-next\s+(\d+)|-(start)|-(end))?\b`)
+	// Menu labels worth translating: an optional "&" accelerator marker
+	// followed by a capitalized word.
+	menuLabelPattern = regexp.MustCompile(`^&?[A-Z][a-zA-Z]`)
+	// Electron dialog calls whose arguments are shown to the user; the
+	// submatch tells an error box from a message box.
+	dialogCallPattern = regexp.MustCompile(`\bshow(ErrorBox|MessageBox(?:Sync)?)\(`)
+	// A single-line string literal; one of the three submatches is set.
+	stringLiteralPattern = regexp.MustCompile(`'([^']*)'|"([^"]*)"|\x60([^\x60]*)\x60`)
+	// The text properties of Electron MessageBoxOptions.
+	messageBoxFieldPattern = regexp.MustCompile(`\b(?:title|message|detail):\s*(?:'([^']*)'|"([^"]*)"|\x60([^\x60]*)\x60)`)
+	// Default values of component props, e.g. default: 'System Integration'.
+	propDefaultPattern = regexp.MustCompile(`\bdefault:\s*['"]([A-Z][^'"]*)['"]`)
+	// Error objects built from a string literal, e.g. new Error('No pod found').
+	newErrorPattern = regexp.MustCompile(`\bnew Error\(\s*(?:'([^']*)'|"([^"]*)"|\x60([^\x60]*)\x60)`)
+	// Dialog text worth translating: a capitalized word.
+	dialogTextPattern = regexp.MustCompile(`^[A-Z][a-zA-Z]`)
 )
 
 func runUntranslated(args []string) error {
 	fs := flag.NewFlagSet("untranslated", flag.ExitOnError)
-	format := fs.String("format", "text", "Output format: text, json")
+	format := fs.String("format", "text", "Output format: text, json, sarif")
 	includeDescriptions := fs.Bool("include-descriptions", false, "Include 'description' fields (catches diagnostics strings)")
+	sortBy := fs.String("sort-by", "file", "Sort order: file, confidence (most confident first)")
+	minConfidence := fs.Int("min-confidence", 0, "Only report hits with at least this confidence (1-100)")
+	minLength := fs.Int("min-length", 0, "Only report hits whose matched string is at least this many characters")
+	since := fs.String("since", "", "Only scan source files changed since this git ref")
+	suggest := fs.Bool("suggest", false, "Propose a new en-us.yaml key for each hit, from its file path and value")
+	exclude := excludeFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of untranslated:\n")
+		fs.PrintDefaults()
+		fmt.Fprint(fs.Output(), untranslatedIgnoreHelp)
+	}
 	fs.Parse(args)
 
+	if err := checkFormat(*format, "text", "json", "sarif"); err != nil {
+		return err
+	}
+
+	if *sortBy != "file" && *sortBy != "confidence" {
+		return fmt.Errorf("unknown --sort-by %q (valid: file, confidence)", *sortBy)
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return err
 	}
-	return reportUntranslated(root, *format, *includeDescriptions)
+	return reportUntranslated(root, *format, untranslatedOptions{
+		includeDescriptions: *includeDescriptions,
+		sortByConfidence:    *sortBy == "confidence",
+		minConfidence:       *minConfidence,
+		minLength:           *minLength,
+		since:               *since,
+		exclude:             *exclude,
+		suggest:             *suggest,
+	})
+}
+
+// untranslatedIgnoreHelp documents the suppression directives in the
+// subcommand help.
+const untranslatedIgnoreHelp = `
+Suppress hits with a comment in the source file:
+  // i18n-report-ignore            ignore this line
+  // i18n-report-ignore-next N     ignore the next N lines
+  // i18n-report-ignore-start      ignore every line from here
+  // i18n-report-ignore-end        through here
+In Vue templates use <!-- i18n-report-ignore --> and so on.
+`
+
+// untranslatedOptions holds the optional behaviors of the untranslated
+// subcommand.
+type untranslatedOptions struct {
+	includeDescriptions bool   // also match description properties
+	sortByConfidence    bool   // most confident hits first
+	minConfidence       int    // drop hits scoring below this
+	minLength           int    // drop hits whose value has fewer runes
+	since               string // git ref; scan only files changed since it
+	exclude             []string
+	suggest             bool // propose a key for each hit
 }
 
-func reportUntranslated(root, format string, includeDescriptions bool) error {
-	hits, err := findUntranslated(root, includeDescriptions)
+func reportUntranslated(root, format string, opts untranslatedOptions) error {
+	var only map[string]bool
+	if opts.since != "" {
+		only = sinceFileSet(root, opts.since)
+	}
+	hits, err := findUntranslated(root, opts.includeDescriptions, only, opts.exclude)
 	if err != nil {
 		return err
 	}
+	hits = rankUntranslated(hits, opts)
+
+	if opts.suggest {
+		enKeys, err := loadYAMLFlat(translationsPath(root, "en-us.yaml"))
+		if err != nil {
+			return err
+		}
+		suggestKeys(hits, enKeys)
+	}
 
 	if format == "json" {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		return enc.Encode(hits)
 	}
+	if format == "sarif" {
+		return writeUntranslatedSARIF(os.Stdout, hits)
+	}
 
 	if len(hits) == 0 {
 		fmt.Println("No untranslated strings found.")
@@ -68,22 +183,67 @@ func reportUntranslated(root, format string, includeDescriptions bool) error {
 
 	fmt.Printf("Found %d potential untranslated strings:\n\n", len(hits))
 	for _, h := range hits {
-		fmt.Printf("  %s:%d\n    %s\n\n", h.File, h.Line, h.Context)
+		if h.Confidence < lowConfidence {
+			fmt.Printf("  %s:%d (low confidence %d)\n    %s\n", h.File, h.Line, h.Confidence, h.Context)
+		} else {
+			fmt.Printf("  %s:%d\n    %s\n", h.File, h.Line, h.Context)
+		}
+		if h.Suggestion != "" {
+			fmt.Printf("    suggested key: %s\n", h.Suggestion)
+		}
+		fmt.Println()
 	}
 	return nil
 }
 
+// rankUntranslated drops hits below the minimum confidence or value
+// length and, if requested, orders the rest by confidence, keeping file
+// order for ties.
+func rankUntranslated(hits []untranslatedHit, opts untranslatedOptions) []untranslatedHit {
+	var kept []untranslatedHit
+	for _, h := range hits {
+		if h.Confidence >= opts.minConfidence && len([]rune(h.Value)) >= opts.minLength {
+			kept = append(kept, h)
+		}
+	}
+	if opts.sortByConfidence {
+		sort.SliceStable(kept, func(i, j int) bool {
+			return kept[i].Confidence > kept[j].Confidence
+		})
+	}
+	return kept
+}
+
 // findUntranslated uses heuristics to find hardcoded English strings in Vue/TS files.
+// .tsx and .jsx files are scanned too, but only with the script heuristics: the
+// attribute and tag-text checks assume Vue templates and stay behind isVue.
 // When includeDescriptions is true, the dialog pattern also matches "description" properties
 // (catches diagnostics strings in main/diagnostics/*.ts).
 //
-// Known gaps: Electron menu labels (main/mainmenu.ts), error dialog calls
-// (showErrorBox in tray.ts, settingsImpl.ts), port forwarding error messages
-// (backend/kube/client.ts), and template-literal strings lack a reliable
-// structural pattern to scan for without drowning in false positives.
-func findUntranslated(root string, includeDescriptions bool) ([]untranslatedHit, error) {
+// Electron menu templates (files that define submenu arrays or call
+// buildFromTemplate, such as main/mainmenu.ts) are also parsed for
+// hardcoded item labels; see findMenuLabels.
+//
+// Component prop defaults (default: 'Some Text') are checked in .ts files
+// and in the <script> sections of .vue files.
+//
+// In .ts files, Electron dialog calls are checked for literal arguments on
+// the line of the call; see dialogCallLiteral.
+//
+// Errors constructed from a capitalized, multi-word literal in .ts files
+// (new Error('Pod has no name')) may reach the user, as in the port
+// forwarding code in backend/kube/client.ts; see newErrorLiteral. Many are
+// only logged, so they score low, and template literals with
+// interpolation lower still.
+//
+// Known gap: other template-literal strings lack a reliable structural
+// pattern to scan for without drowning in false positives.
+//
+// When only is non-nil, files outside it (by absolute path) are skipped,
+// as are paths matching an exclude glob (see scanSourceFiles).
+func findUntranslated(root string, includeDescriptions bool, only map[string]bool, exclude []string) ([]untranslatedHit, error) {
 	srcDir := filepath.Join(root, "pkg", "rancher-desktop")
-	files, err := scanSourceFiles(srcDir, []string{".vue", ".ts"})
+	files, err := scanSourceFiles(root, srcDir, []string{".vue", ".ts", ".tsx", ".jsx"}, exclude)
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +258,7 @@ func findUntranslated(root string, includeDescriptions bool) ([]untranslatedHit,
 	dialogPattern := regexp.MustCompile(`(` + dialogFields + `):\s+['"]([A-Z][^'"]{5,})['"]`)
 
 	for _, file := range files {
-		base := filepath.Base(file)
-		if strings.Contains(base, ".spec.") || strings.Contains(base, ".test.") {
+		if isTestFile(file) || (only != nil && !only[file]) {
 			continue
 		}
 		data, err := os.ReadFile(file)
@@ -109,11 +268,29 @@ func findUntranslated(root string, includeDescriptions bool) ([]untranslatedHit,
 		relPath, _ := filepath.Rel(root, file)
 		lines := strings.Split(string(data), "\n")
 		isVue := strings.HasSuffix(file, ".vue")
+		isScript := !isVue
 		isTS := strings.HasSuffix(file, ".ts")
 		inTemplate := false
+		inScript := false
+
+		// Electron menu templates span many lines, so they are parsed
+		// structurally rather than line by line.
+		ignored := ignoredLines(lines)
+		menuLines := make(map[int]bool)
+		if isScript && menuTemplateHint.Match(data) {
+			for _, h := range findMenuLabels(relPath, string(data)) {
+				menuLines[h.Line] = true
+				if !ignored[h.Line] {
+					hits = append(hits, h)
+				}
+			}
+		}
 
 		for i, line := range lines {
 			trimmed := strings.TrimSpace(line)
+			if menuLines[i+1] {
+				continue
+			}
 
 			// Track top-level Vue <template> sections (not nested slot templates).
 			if isVue {
@@ -125,6 +302,10 @@ func findUntranslated(root string, includeDescriptions bool) ([]untranslatedHit,
 					if len(line)-len(strings.TrimLeft(line, " \t")) == 0 {
 						inTemplate = false
 					}
+				} else if strings.HasPrefix(line, "<script") {
+					inScript = true
+				} else if strings.HasPrefix(line, "</script>") {
+					inScript = false
 				}
 			}
 
@@ -136,32 +317,31 @@ func findUntranslated(root string, includeDescriptions bool) ([]untranslatedHit,
 				continue
 			}
 
-			found := false
+			// confidence and value are set by the first heuristic that
+			// matches.
+			confidence := 0
+			value := ""
 
 			if isVue {
 				// Check unbound attribute values.
 				matches := attrPattern.FindAllStringSubmatch(trimmed, -1)
 				for _, m := range matches {
-					value := m[2]
-					if skipPattern.MatchString(value) {
-						continue
-					}
-					if strings.Contains(value, " ") || singleWordTitleCase.MatchString(value) {
-						found = true
+					if isEnglishLabel(m[2]) {
+						confidence, value = confidenceAttribute, m[2]
 						break
 					}
 				}
 
 				// Check text between HTML tags on the same line.
 				// Skip <slot> default content — it's fallback text overridden by parents.
-				if !found && !strings.Contains(trimmed, "<slot>") {
+				if confidence == 0 && !strings.Contains(trimmed, "<slot>") {
 					tagMatches := htmlTextPattern.FindAllStringSubmatch(trimmed, -1)
 					for _, m := range tagMatches {
-						value := strings.TrimSpace(m[1])
-						if skipPattern.MatchString(value) {
+						text := strings.TrimSpace(m[1])
+						if skipPattern.MatchString(text) {
 							continue
 						}
-						found = true
+						confidence, value = confidenceInlineText, text
 						break
 					}
 				}
@@ -169,40 +349,295 @@ func findUntranslated(root string, includeDescriptions bool) ([]untranslatedHit,
 				// Check bare text between tags across lines: previous line
 				// ends with ">", this line is bare text, next line starts
 				// with "</" or "<".
-				if !found && inTemplate && bareTextPattern.MatchString(trimmed) {
+				if confidence == 0 && inTemplate && bareTextPattern.MatchString(trimmed) {
 					prevEndsWithTag := i > 0 && strings.HasSuffix(strings.TrimSpace(lines[i-1]), ">")
 					nextStartsWithTag := i+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i+1]), "<")
 					if prevEndsWithTag && nextStartsWithTag {
-						found = true
+						confidence, value = confidenceBareText, trimmed
 					}
 				}
 
 				// Check bound string literal attributes.
-				if !found && boundLiteralPattern.MatchString(trimmed) {
-					found = true
+				if confidence == 0 {
+					if m := boundLiteralPattern.FindStringSubmatch(trimmed); m != nil {
+						confidence, value = confidenceBoundLiteral, m[2]
+					}
 				}
 			}
 
-			if !found && isTS {
+			if confidence == 0 && isScript {
 				// Validation error messages.
-				if errorPushPattern.MatchString(trimmed) {
-					found = true
+				if m := errorPushPattern.FindStringSubmatch(trimmed); m != nil {
+					confidence, value = confidenceErrorPush, m[1]
+				}
+			}
+
+			if confidence == 0 && isTS {
+				if v := dialogCallLiteral(trimmed); v != "" {
+					confidence, value = confidenceDialogCall, v
+				}
+			}
+
+			if confidence == 0 && isTS {
+				if v, interpolated := newErrorLiteral(trimmed); v != "" {
+					confidence, value = confidenceNewError, v
+					if interpolated {
+						confidence = confidenceErrorFormat
+					}
+				}
+			}
+
+			// Prop defaults, only in script code so that template text is
+			// not counted twice.
+			if confidence == 0 && (isTS || inScript) {
+				if m := propDefaultPattern.FindStringSubmatch(trimmed); m != nil && isEnglishLabel(m[1]) {
+					confidence, value = confidencePropDefault, m[1]
 				}
 			}
 
 			// Dialog strings in both .vue and .ts files.
-			if !found && dialogPattern.MatchString(trimmed) {
-				found = true
+			if confidence == 0 {
+				if m := dialogPattern.FindStringSubmatch(trimmed); m != nil {
+					confidence, value = confidenceDialog, m[2]
+				}
 			}
 
-			if found {
+			if confidence > 0 && !ignored[i+1] {
 				hits = append(hits, untranslatedHit{
-					File:    relPath,
-					Line:    i + 1,
-					Context: trimmed,
+					File:       relPath,
+					Line:       i + 1,
+					Context:    trimmed,
+					Value:      value,
+					Confidence: confidence,
 				})
 			}
 		}
 	}
 	return hits, nil
 }
+
+// ignoredLines returns the 1-based line numbers suppressed by
+// i18n-report-ignore directives. A plain directive suppresses its own
+// line, "-next N" the N lines after it, and "-start" every line through
+// the next "-end" (or the end of the file). Directive lines themselves
+// are always suppressed.
+func ignoredLines(lines []string) map[int]bool {
+	ignored := make(map[int]bool)
+	inBlock := false
+	next := 0
+	for i, line := range lines {
+		n := i + 1
+		if inBlock || next > 0 {
+			ignored[n] = true
+		}
+		if next > 0 {
+			next--
+		}
+		m := ignoreDirective.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		ignored[n] = true
+		switch {
+		case m[1] != "":
+			next, _ = strconv.Atoi(m[1])
+		case m[2] != "":
+			inBlock = true
+		case m[3] != "":
+			inBlock = false
+		}
+	}
+	return ignored
+}
+
+// dialogCallLiteral returns the first hardcoded English string passed to
+// an Electron dialog call on the line: a positional argument of
+// showErrorBox, or a title, message, or detail property of showMessageBox
+// options written on the same line. It returns "" when there is none.
+func dialogCallLiteral(line string) string {
+	m := dialogCallPattern.FindStringSubmatchIndex(line)
+	if m == nil {
+		return ""
+	}
+	pattern := messageBoxFieldPattern
+	if line[m[2]:m[3]] == "ErrorBox" {
+		pattern = stringLiteralPattern
+	}
+	for _, lit := range pattern.FindAllStringSubmatch(line[m[1]:], -1) {
+		value := lit[1] + lit[2] + lit[3]
+		if dialogTextPattern.MatchString(value) && !strings.Contains(value, "${") {
+			return value
+		}
+	}
+	return ""
+}
+
+// isEnglishLabel reports whether an attribute-like value reads as English
+// text: several words, or one Title Case word, and not an identifier, URL,
+// or other value skipPattern rules out.
+func isEnglishLabel(value string) bool {
+	if skipPattern.MatchString(value) {
+		return false
+	}
+	return strings.Contains(value, " ") || singleWordTitleCase.MatchString(value)
+}
+
+// newErrorLiteral returns the message of a new Error(...) call on the
+// line when it is a capitalized, multi-word string literal, and whether it
+// is a template literal with interpolation. It returns "" otherwise.
+func newErrorLiteral(line string) (string, bool) {
+	m := newErrorPattern.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	value := m[1] + m[2] + m[3]
+	if !dialogTextPattern.MatchString(value) || !strings.Contains(value, " ") || skipPattern.MatchString(value) {
+		return "", false
+	}
+	return value, m[3] != "" && strings.Contains(value, "${")
+}
+
+// menuFrame is an open object or array while parsing a menu template.
+type menuFrame struct {
+	array     bool              // '[' rather than '{'
+	submenu   bool              // array is the value of a submenu property
+	menuItem  bool              // object has menu item properties
+	labels    []untranslatedHit // hardcoded label literals in this object
+	nextArray bool              // internal: a submenu property awaits its '['
+}
+
+// findMenuLabels parses Electron menu templates in TypeScript source and
+// returns hardcoded English labels and sublabels. An object counts as a
+// menu item when it sits inside a submenu array or has menu item
+// properties (submenu, role, click, accelerator, type). Only string
+// literal labels are reported; labels computed by t() or other
+// expressions are not.
+func findMenuLabels(relPath, src string) []untranslatedHit {
+	lines := strings.Split(src, "\n")
+	var hits []untranslatedHit
+	var stack []*menuFrame
+	line := 1
+	lastIdent := ""
+	pendingProp := ""
+
+	top := func() *menuFrame {
+		if len(stack) == 0 {
+			return nil
+		}
+		return stack[len(stack)-1]
+	}
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			line++
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			i += 2
+			for i+1 < len(src) && !(src[i] == '*' && src[i+1] == '/') {
+				if src[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			i++
+		case c == '\'' || c == '"' || c == '\x60':
+			start, startLine := i+1, line
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' {
+					i++
+				} else if src[i] == '\n' {
+					line++
+				}
+			}
+			value := src[start:min(i, len(src))]
+			if (pendingProp == "label" || pendingProp == "sublabel") && top() != nil && !(c == '\x60' && strings.Contains(value, "${")) {
+				if menuLabelPattern.MatchString(value) {
+					top().labels = append(top().labels, untranslatedHit{
+						File:       relPath,
+						Line:       startLine,
+						Context:    strings.TrimSpace(lines[startLine-1]),
+						Value:      value,
+						Confidence: confidenceMenuLabel,
+					})
+				}
+			}
+			pendingProp = ""
+			lastIdent = ""
+		case c == ':':
+			pendingProp = lastIdent
+			if fr := top(); fr != nil && !fr.array {
+				switch lastIdent {
+				case "submenu", "role", "click", "accelerator", "type":
+					fr.menuItem = true
+				}
+				fr.nextArray = lastIdent == "submenu"
+			}
+			lastIdent = ""
+		case c == '(':
+			// click() { ... } method shorthand marks a menu item.
+			if lastIdent == "click" {
+				if fr := top(); fr != nil && !fr.array {
+					fr.menuItem = true
+				}
+			}
+			lastIdent = ""
+			pendingProp = ""
+		case c == '{' || c == '[':
+			frame := &menuFrame{array: c == '['}
+			if fr := top(); fr != nil && frame.array && fr.nextArray {
+				frame.submenu = true
+			}
+			if fr := top(); fr != nil {
+				fr.nextArray = false
+			}
+			stack = append(stack, frame)
+			lastIdent = ""
+			pendingProp = ""
+		case c == '}' || c == ']':
+			if len(stack) == 0 {
+				continue
+			}
+			frame := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !frame.array {
+				parent := top()
+				if frame.menuItem || (parent != nil && parent.submenu) {
+					hits = append(hits, frame.labels...)
+				}
+			}
+			lastIdent = ""
+			pendingProp = ""
+		case isIdentByte(c):
+			start := i
+			for i+1 < len(src) && isIdentByte(src[i+1]) {
+				i++
+			}
+			lastIdent = src[start : i+1]
+		case c == ' ' || c == '\t' || c == '\r':
+			// Whitespace keeps the pending identifier/property.
+		case c == '?':
+			// Ternary: the first branch is still the property's value.
+			lastIdent = ""
+		default:
+			if fr := top(); fr != nil {
+				fr.nextArray = false
+			}
+			lastIdent = ""
+			pendingProp = ""
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Line < hits[j].Line })
+	return hits
+}
+
+// isIdentByte reports whether c can appear in a JavaScript identifier.
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -1,6 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -188,3 +193,367 @@ func TestErrorPushPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestPropDefaultPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		wantVal string // empty means no match
+	}{
+		{"multi-word", `default: 'System Integration',`, "System Integration"},
+		{"double quotes", `default: "Select a file",`, "Select a file"},
+		{"title case word", `default:   'Cancel',`, "Cancel"},
+		{"constant", `default:   'IMAGE_MANAGER_UNREADY',`, ""},
+		{"lowercase", `default: 'primary',`, ""},
+		{"empty", `default: '',`, ""},
+		{"factory", `default: () => ({}),`, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			if m := propDefaultPattern.FindStringSubmatch(tc.line); m != nil && isEnglishLabel(m[1]) {
+				got = m[1]
+			}
+			if tc.wantVal == "" && got != "" {
+				t.Errorf("expected no match, got %q", got)
+			} else if tc.wantVal != "" && got != tc.wantVal {
+				t.Errorf("got %q, want %q", got, tc.wantVal)
+			}
+		})
+	}
+}
+
+func TestFindUntranslatedPropDefaults(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "components")
+	os.MkdirAll(srcDir, 0755)
+	vue := `<template>
+  <section :title="title">
+    default: 'Not In A Script'
+  </section>
+</template>
+
+<script lang="ts">
+export default defineComponent({
+  props: {
+    title: {
+      type:    String,
+      default: 'System Integration',
+    },
+  },
+});
+</script>
+`
+	os.WriteFile(filepath.Join(srcDir, "Panel.vue"), []byte(vue), 0644)
+	ts := "export const props = {\n  label: { type: String, default: 'Click Here' },\n};\n"
+	os.WriteFile(filepath.Join(srcDir, "props.ts"), []byte(ts), 0644)
+
+	hits, err := findUntranslated(root, false, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, h := range hits {
+		got = append(got, fmt.Sprintf("%s:%d %s", filepath.Base(h.File), h.Line, h.Value))
+	}
+	want := []string{"Panel.vue:12 System Integration", "props.ts:2 Click Here"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFindMenuLabels(t *testing.T) {
+	src := `import Electron, { MenuItemConstructorOptions } from 'electron';
+
+const editMenu: MenuItemConstructorOptions = {
+  label:   '&Edit',
+  submenu: [
+    { role: 'undo', label: t('mainMenu.edit.undo') },
+    { role: 'redo', label: 'Redo' },
+    { type: 'separator' },
+    {
+      label:   'Advanced',
+      submenu: [
+        { label: 'Reset Zoom', click() { reset(); } },
+        { label: "it's {braces} // not a comment", role: 'zoom' },
+      ],
+    },
+  ],
+};
+
+const helpItems: MenuItemConstructorOptions[] = [
+  {
+    label: isMac ? 'Help' : t('mainMenu.help.getHelp'),
+    click: async() => {
+      shell.openExternal('https://example.com/');
+    },
+  },
+  { label: appName, role: 'about' },
+  { label: ` + "`Version ${ version }`" + `, enabled: false },
+];
+
+// Not a menu: a plain object with a label.
+const option = { label: 'Not A Menu Item', value: 1 };
+`
+	hits := findMenuLabels("main/mainmenu.ts", src)
+
+	var got []int
+	for _, h := range hits {
+		got = append(got, h.Line)
+	}
+	// &Edit, Redo, Advanced, Reset Zoom, Help.
+	want := []int{4, 7, 10, 12, 21}
+	if len(got) != len(want) {
+		t.Fatalf("got lines %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got lines %v, want %v", got, want)
+		}
+	}
+	if hits[1].Context != "{ role: 'redo', label: 'Redo' }," {
+		t.Errorf("context = %q", hits[1].Context)
+	}
+}
+
+func TestFindMenuSublabels(t *testing.T) {
+	src := `const menu = Menu.buildFromTemplate([
+  {
+    label:    t('tray.status'),
+    sublabel: 'Kubernetes is running',
+    submenu:  [{ role: 'quit', sublabel: t('tray.quitHint') }],
+  },
+]);
+`
+	hits := findMenuLabels("main/tray.ts", src)
+	if len(hits) != 1 || hits[0].Line != 4 || hits[0].Value != "Kubernetes is running" {
+		t.Errorf("got %+v, want one sublabel hit on line 4", hits)
+	}
+}
+
+func TestDialogCallLiteral(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		wantVal string // empty means no match
+	}{
+		{"error box title", `dialog.showErrorBox('Unable To Save Settings File', parseSaveError(err));`, "Unable To Save Settings File"},
+		{"error box message", `Electron.dialog.showErrorBox(title, "Something went wrong");`, "Something went wrong"},
+		{"error box first line", `Electron.dialog.showErrorBox('Error starting the app:',`, "Error starting the app:"},
+		{"error box interpolation", "dialog.showErrorBox(title, `Error: ${ err }`);", ""},
+		{"message box message", `await dialog.showMessageBox({ message: 'Restart required', type: 'info' });`, "Restart required"},
+		{"message box lowercase type", `dialog.showMessageBoxSync(window, { type: 'error' });`, ""},
+		{"message box options", `await showMessageBox(options, true);`, ""},
+		{"not a dialog", `console.log('Settings file saved');`, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := dialogCallLiteral(tc.line)
+			if tc.wantVal == "" && got != "" {
+				t.Errorf("expected no match, got %q", got)
+			} else if tc.wantVal != "" && got != tc.wantVal {
+				t.Errorf("got %q, want %q", got, tc.wantVal)
+			}
+		})
+	}
+}
+
+func TestNewErrorLiteral(t *testing.T) {
+	tests := []struct {
+		name             string
+		line             string
+		wantVal          string // empty means no match
+		wantInterpolated bool
+	}{
+		{"single quotes", `throw new Error('Pod has no name');`, "Pod has no name", false},
+		{"double quotes", `throw new Error("Invalid option: can't be empty");`, "Invalid option: can't be empty", false},
+		{"template", "return Promise.reject(new Error(`No free port found`));", "No free port found", false},
+		{"interpolated", "throw new Error(`Could not find port number for pod ${ podName }`);", "Could not find port number for pod ${ podName }", true},
+		{"single word", `throw new Error('Timeout');`, "", false},
+		{"lowercase", `throw new Error('invalid state here');`, "", false},
+		{"variable", `throw new Error(message);`, "", false},
+		{"subclass", `throw new TypeError('Bad value given');`, "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, interpolated := newErrorLiteral(tc.line)
+			if tc.wantVal == "" && got != "" {
+				t.Errorf("expected no match, got %q", got)
+			} else if got != tc.wantVal || interpolated != tc.wantInterpolated {
+				t.Errorf("got %q, %v; want %q, %v", got, interpolated, tc.wantVal, tc.wantInterpolated)
+			}
+		})
+	}
+}
+
+func TestFindUntranslatedDialogCalls(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop")
+	os.MkdirAll(filepath.Join(srcDir, "main"), 0755)
+	os.MkdirAll(filepath.Join(srcDir, "components"), 0755)
+	ts := "try {\n" +
+		"  save();\n" +
+		"} catch (err) {\n" +
+		"  dialog.showErrorBox('Save Failed', String(err));\n" +
+		"}\n"
+	os.WriteFile(filepath.Join(srcDir, "main", "save.ts"), []byte(ts), 0644)
+	// A Vue component wrapping a method of the same name is not an
+	// Electron dialog.
+	vue := "<script>\nthis.showErrorBox('Save Failed', detail);\n</script>\n"
+	os.WriteFile(filepath.Join(srcDir, "components", "Save.vue"), []byte(vue), 0644)
+
+	hits, err := findUntranslated(root, false, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Line != 4 || hits[0].Value != "Save Failed" || hits[0].Confidence != confidenceDialogCall {
+		t.Errorf("got %+v, want one error box hit in save.ts", hits)
+	}
+}
+
+func TestReportUntranslatedTextConfidence(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "main")
+	os.MkdirAll(srcDir, 0755)
+	ts := "dialog.showErrorBox('Save Failed', String(err));\n" +
+		"throw new Error(`Could not save ${ name }`);\n"
+	os.WriteFile(filepath.Join(srcDir, "save.ts"), []byte(ts), 0644)
+
+	out, err := captureStdout(t, func() error {
+		return reportUntranslated(root, "text", untranslatedOptions{})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the guess is marked with its score.
+	file := filepath.Join("pkg", "rancher-desktop", "main", "save.ts")
+	for _, want := range []string{file + ":1\n", fmt.Sprintf("%s:2 (low confidence %d)\n", file, confidenceErrorFormat)} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRankUntranslated(t *testing.T) {
+	hits := []untranslatedHit{
+		{File: "a.vue", Line: 1, Confidence: confidenceBareText},
+		{File: "a.vue", Line: 2, Confidence: confidenceBoundLiteral},
+		{File: "b.vue", Line: 1, Confidence: confidenceInlineText},
+		{File: "b.vue", Line: 2, Confidence: confidenceBoundLiteral},
+	}
+
+	got := rankUntranslated(hits, untranslatedOptions{sortByConfidence: true, minConfidence: 50})
+	var lines []string
+	for _, h := range got {
+		lines = append(lines, fmt.Sprintf("%s:%d", h.File, h.Line))
+	}
+	want := "a.vue:2 b.vue:2 b.vue:1"
+	if strings.Join(lines, " ") != want {
+		t.Errorf("got %v, want %s", lines, want)
+	}
+
+	if got := rankUntranslated(hits, untranslatedOptions{}); len(got) != 4 || got[0].Line != 1 || got[0].File != "a.vue" {
+		t.Errorf("default options changed order or filtered: %+v", got)
+	}
+}
+
+func TestIgnoredLines(t *testing.T) {
+	lines := []string{
+		`<span label="Debug Only" /> <!-- i18n-report-ignore -->`,
+		`<span label="Shown" />`,
+		`// i18n-report-ignore-next 2`,
+		`title: 'One',`,
+		`title: 'Two',`,
+		`title: 'Three',`,
+		`<!-- i18n-report-ignore-start -->`,
+		`<p>Internal</p>`,
+		`<!-- i18n-report-ignore-end -->`,
+		`<p>Visible</p>`,
+	}
+	got := ignoredLines(lines)
+	want := map[int]bool{1: true, 3: true, 4: true, 5: true, 7: true, 8: true, 9: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRankUntranslatedMinLength(t *testing.T) {
+	hits := []untranslatedHit{
+		{File: "a.vue", Line: 1, Value: "OK", Confidence: 80},
+		{File: "a.vue", Line: 2, Value: "Übersicht", Confidence: 60},
+		{File: "a.vue", Line: 3, Value: "Save changes", Confidence: 90},
+	}
+	got := rankUntranslated(hits, untranslatedOptions{minLength: 9})
+	if len(got) != 2 || got[0].Line != 2 || got[1].Line != 3 {
+		t.Errorf("got %+v, want lines 2 and 3", got)
+	}
+	if got := rankUntranslated(hits, untranslatedOptions{}); len(got) != 3 {
+		t.Errorf("default dropped hits: %+v", got)
+	}
+}
+
+func TestFindUntranslatedTSX(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "components")
+	os.MkdirAll(srcDir, 0755)
+	src := "export const Dialog = () => {\n" +
+		"  const opts = { title: 'Delete Everything' };\n" +
+		"  return <Button label=\"Save Changes\">Cancel Now</Button>;\n" +
+		"};\n"
+	os.WriteFile(filepath.Join(srcDir, "Dialog.tsx"), []byte(src), 0644)
+
+	hits, err := findUntranslated(root, false, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the dialog string counts; the JSX attribute and text are not
+	// checked by the Vue template heuristics.
+	if len(hits) != 1 || hits[0].Line != 2 || hits[0].Value != "Delete Everything" {
+		t.Errorf("got %+v, want one dialog hit on line 2", hits)
+	}
+}
+
+func TestFindUntranslatedValues(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "components")
+	os.MkdirAll(srcDir, 0755)
+	vue := `<template>
+  <div>
+    <input placeholder="Enter a name">
+    <h1>Welcome Back</h1>
+    <input :placeholder="'Search images'">
+    <p>
+      Continue
+    </p>
+  </div>
+</template>
+<script>
+const opts = { title: 'Delete Everything' };
+</script>
+`
+	os.WriteFile(filepath.Join(srcDir, "Form.vue"), []byte(vue), 0644)
+	os.WriteFile(filepath.Join(srcDir, "validate.ts"), []byte("errors.push('Name is required');\n"), 0644)
+
+	hits, err := findUntranslated(root, false, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, h := range hits {
+		got[fmt.Sprintf("%s:%d", filepath.Base(h.File), h.Line)] = h.Value
+	}
+	want := map[string]string{
+		"Form.vue:3":    "Enter a name",
+		"Form.vue:4":    "Welcome Back",
+		"Form.vue:5":    "Search images",
+		"Form.vue:7":    "Continue",
+		"Form.vue:12":   "Delete Everything",
+		"validate.ts:1": "Name is required",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -1,39 +1,412 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 func runUnused(args []string) error {
 	fs := flag.NewFlagSet("unused", flag.ExitOnError)
-	format := fs.String("format", "text", "Output format: text, json")
+	format := fs.String("format", "text", "Output format: text, json, yaml")
+	rollUp := fs.Bool("roll-up", false, "Collapse namespaces whose keys are all unused into a single prefix entry")
+	expand := fs.Bool("expand", false, "With --roll-up, list the keys under each collapsed prefix")
+	group := fs.Bool("group", false, "Group unused keys by top-level segment, largest group first")
+	fs.BoolVar(group, "sort-by-count", false, "Alias for --group")
+	summary := fs.Bool("summary", false, "With --group, print only the key count of each group")
+	lintCalls := fs.Bool("lint-calls", false, "Warn about t() key literals with leading/trailing whitespace")
+	interactive := fs.Bool("interactive", false, "Review each unused key and choose whether to delete it (requires a terminal)")
+	since := fs.String("since", "", "Only report keys whose references were removed from files changed since this git ref")
+	withLines := fs.Bool("with-lines", false, "Show the en-us.yaml line defining each unused key")
+	scanOpts := scanFlags(fs)
+	source := sourceLocaleFlag(fs)
 	fs.Parse(args)
 
+	if err := checkFormat(*format, "text", "json", "yaml"); err != nil {
+		return err
+	}
+
+	if *since != "" && (*rollUp || *interactive) {
+		return fmt.Errorf("--since cannot be combined with --roll-up or --interactive")
+	}
+	if *format == "yaml" && (*rollUp || *withLines || *group) {
+		return fmt.Errorf("--format=yaml cannot be combined with --roll-up, --with-lines, or --group")
+	}
+	if *group && (*rollUp || *withLines || *interactive) {
+		return fmt.Errorf("--group cannot be combined with --roll-up, --with-lines, or --interactive")
+	}
+	if *summary && !*group {
+		return fmt.Errorf("--summary requires --group")
+	}
+	if *interactive && outputPath != "" {
+		return fmt.Errorf("--interactive cannot be combined with --output")
+	}
+	if *withLines && (*rollUp || *interactive) {
+		return fmt.Errorf("--with-lines cannot be combined with --roll-up or --interactive")
+	}
+	scan, err := scanOpts()
+	if err != nil {
+		return err
+	}
 	root, err := repoRoot()
 	if err != nil {
 		return err
 	}
-	return reportUnused(root, *format)
+	return reportUnused(root, *format, unusedOptions{
+		rollUp:       *rollUp,
+		expand:       *expand,
+		lintCalls:    *lintCalls,
+		interactive:  *interactive,
+		since:        *since,
+		withLines:    *withLines,
+		group:        *group,
+		summary:      *summary,
+		sourceLocale: *source,
+		scan:         scan,
+	})
+}
+
+// unusedOptions holds the optional behaviors of the unused subcommand.
+type unusedOptions struct {
+	rollUp       bool   // collapse fully unused namespaces
+	expand       bool   // list keys under collapsed namespaces
+	lintCalls    bool   // warn about suspicious t() calls on stderr
+	interactive  bool   // prompt to delete each unused key
+	since        string // git ref; report only references removed since it
+	withLines    bool   // show where each key is defined
+	group        bool   // group keys by top-level segment
+	summary      bool   // with group, omit the keys under each group
+	sourceLocale string // locale whose keys need a reference; empty for en-us
+	scan         scanOptions
 }
 
-func reportUnused(root, format string) error {
-	enPath := translationsPath(root, "en-us.yaml")
+func reportUnused(root, format string, opts unusedOptions) error {
+	enPath, err := sourceLocalePath(root, opts.sourceLocale)
+	if err != nil {
+		return err
+	}
 	keys, err := loadYAMLFlat(enPath)
 	if err != nil {
 		return err
 	}
 
-	refs, err := findKeyReferences(root, keys)
+	if opts.lintCalls {
+		warnings, err := lintTranslationCalls(root, opts.scan.exclude)
+		if err != nil {
+			return err
+		}
+		printCallWarnings(warnings)
+	}
+
+	if opts.since != "" {
+		files, err := changedSourceFiles(root, opts.since)
+		if err == nil {
+			removed := removedKeyReferences(root, opts.since, files, keys, opts.scan)
+			label := "keys with references removed since " + opts.since
+			if opts.withLines {
+				return outputKeyLines(removed, enPath, format, label)
+			}
+			if opts.group {
+				return outputKeyGroups(groupByTopLevel(removed), format, opts.summary, label)
+			}
+			return outputStrings(removed, format, label)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v; scanning all files\n", err)
+	}
+
+	refs, err := findKeyReferences(root, keys, opts.scan)
 	if err != nil {
 		return err
 	}
 
+	unused := unusedKeys(keys, refs)
+
+	if opts.interactive {
+		if !isTerminal(os.Stdin) {
+			return fmt.Errorf("--interactive requires stdin to be a terminal")
+		}
+		toDelete, err := triageUnused(keys, unused, os.Stdin, os.Stdout)
+		if err != nil {
+			return err
+		}
+		if len(toDelete) == 0 {
+			fmt.Println("No keys selected for deletion.")
+			return nil
+		}
+		return removeKeysFromAll(root, toDelete, removeOptions{})
+	}
+
+	if opts.rollUp {
+		return outputRollUp(rollUpUnused(keys, unused), format, opts.expand)
+	}
+	if opts.withLines {
+		return outputKeyLines(unused, enPath, format, "unused keys")
+	}
+	if opts.group {
+		return outputKeyGroups(groupByTopLevel(unused), format, opts.summary, "unused keys")
+	}
+	return outputStrings(unused, format, "unused keys")
+}
+
+// unusedKeys returns the keys with no reference, sorted.
+func unusedKeys(keys map[string]string, refs map[string][]keyReference) []string {
 	var unused []string
 	for _, k := range sortedKeys(keys) {
 		if _, found := refs[k]; !found {
 			unused = append(unused, k)
 		}
 	}
+	return unused
+}
 
-	return outputStrings(unused, format, "unused keys")
+// keyLocation is a key with the file and line that define it.
+type keyLocation struct {
+	Key  string `json:"key"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// outputKeyLines prints keys with their defining line in path, as
+// "key  file:line" in text output so editors can jump to it.
+func outputKeyLines(keys []string, path, format, label string) error {
+	defs, err := loadYAMLFlatWithLines(path)
+	if err != nil {
+		return err
+	}
+	file := filepath.ToSlash(filepath.Join(translationsDir, filepath.Base(path)))
+	locations := make([]keyLocation, 0, len(keys))
+	for _, k := range keys {
+		locations = append(locations, keyLocation{Key: k, File: file, Line: defs[k].Line})
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(locations)
+	}
+
+	if len(locations) == 0 {
+		fmt.Printf("No %s found.\n", label)
+		return nil
+	}
+
+	fmt.Printf("Found %d %s:\n", len(locations), label)
+	for _, l := range locations {
+		fmt.Printf("  %s  %s:%d\n", l.Key, l.File, l.Line)
+	}
+	return nil
+}
+
+// keyGroup is the set of keys sharing a top-level segment.
+type keyGroup struct {
+	Name string
+	Keys []string
+}
+
+// groupByTopLevel groups keys by their first dotted segment, largest
+// group first and alphabetically among groups of equal size. Keys keep
+// their order within each group.
+func groupByTopLevel(keys []string) []keyGroup {
+	index := make(map[string]int)
+	var groups []keyGroup
+	for _, k := range keys {
+		name, _, _ := strings.Cut(k, ".")
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, keyGroup{Name: name})
+		}
+		groups[i].Keys = append(groups[i].Keys, k)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].Keys) != len(groups[j].Keys) {
+			return len(groups[i].Keys) > len(groups[j].Keys)
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// outputKeyGroups prints grouped keys: in text, one "name (N)" line per
+// group with its keys below it, or only the counts when summary is true;
+// in JSON, a map of group name to keys.
+func outputKeyGroups(groups []keyGroup, format string, summary bool, label string) error {
+	if format == "json" {
+		byName := make(map[string][]string, len(groups))
+		for _, g := range groups {
+			byName[g.Name] = g.Keys
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(byName)
+	}
+
+	if len(groups) == 0 {
+		fmt.Printf("No %s found.\n", label)
+		return nil
+	}
+
+	count := 0
+	for _, g := range groups {
+		count += len(g.Keys)
+	}
+	fmt.Printf("Found %d %s in %d groups:\n", count, label, len(groups))
+	for _, g := range groups {
+		fmt.Printf("  %s (%d)\n", g.Name, len(g.Keys))
+		if !summary {
+			for _, k := range g.Keys {
+				fmt.Printf("    %s\n", k)
+			}
+		}
+	}
+	return nil
+}
+
+// isTerminal reports whether f is connected to a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// triageUnused walks through the unused keys one at a time, showing each
+// key with its English value and asking whether to delete it. It returns
+// the set of keys the user chose to delete. Quitting (or reaching the end
+// of input) stops the review and keeps the decisions made so far.
+func triageUnused(keys map[string]string, unused []string, in io.Reader, out io.Writer) (map[string]bool, error) {
+	toDelete := make(map[string]bool)
+	reader := bufio.NewReader(in)
+	for i, k := range unused {
+		fmt.Fprintf(out, "\n[%d/%d] %s\n  %s\n", i+1, len(unused), k, keys[k])
+	prompt:
+		for {
+			fmt.Fprint(out, "[d]elete / [s]kip / [q]uit: ")
+			answer, err := reader.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "d":
+				toDelete[k] = true
+				break prompt
+			case "s":
+				break prompt
+			case "q":
+				return toDelete, nil
+			}
+			if err == io.EOF {
+				fmt.Fprintln(out)
+				return toDelete, nil
+			}
+		}
+	}
+	return toDelete, nil
+}
+
+// unusedGroup is either a single unused key or a namespace prefix whose
+// keys are all unused.
+type unusedGroup struct {
+	Prefix string   `json:"prefix,omitempty"`
+	Keys   []string `json:"keys"`
+}
+
+// label returns the text form of a group: the key itself, or
+// "prefix.* (N keys)" for a collapsed namespace.
+func (g unusedGroup) label() string {
+	if g.Prefix == "" {
+		return g.Keys[0]
+	}
+	return fmt.Sprintf("%s.* (%d keys)", g.Prefix, len(g.Keys))
+}
+
+// rollUpUnused groups unused keys under the shortest parent prefix whose
+// keys (as defined in en-us.yaml) are all unused. Prefixes covering a
+// single key are not collapsed, since the prefix would be no shorter than
+// the key itself. The unused slice must be sorted.
+func rollUpUnused(keys map[string]string, unused []string) []unusedGroup {
+	total := make(map[string]int)
+	for k := range keys {
+		for _, p := range parentPrefixes(k) {
+			total[p]++
+		}
+	}
+	dead := make(map[string]int)
+	for _, k := range unused {
+		for _, p := range parentPrefixes(k) {
+			dead[p]++
+		}
+	}
+
+	var groups []unusedGroup
+	byPrefix := make(map[string]int)
+	for _, k := range unused {
+		prefix := ""
+		for _, p := range parentPrefixes(k) {
+			if total[p] >= 2 && dead[p] == total[p] {
+				prefix = p
+				break
+			}
+		}
+		if prefix == "" {
+			groups = append(groups, unusedGroup{Keys: []string{k}})
+			continue
+		}
+		if i, ok := byPrefix[prefix]; ok {
+			groups[i].Keys = append(groups[i].Keys, k)
+			continue
+		}
+		byPrefix[prefix] = len(groups)
+		groups = append(groups, unusedGroup{Prefix: prefix, Keys: []string{k}})
+	}
+	return groups
+}
+
+// parentPrefixes returns the proper ancestor prefixes of a dotted key,
+// shortest first (e.g. "a.b.c" yields "a", "a.b").
+func parentPrefixes(key string) []string {
+	parts := strings.Split(key, ".")
+	prefixes := make([]string, 0, len(parts)-1)
+	for i := 1; i < len(parts); i++ {
+		prefixes = append(prefixes, strings.Join(parts[:i], "."))
+	}
+	return prefixes
+}
+
+// outputRollUp prints rolled-up unused groups in text or JSON format.
+// When expand is true, text output lists the keys under each prefix.
+func outputRollUp(groups []unusedGroup, format string, expand bool) error {
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(groups)
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No unused keys found.")
+		return nil
+	}
+
+	count := 0
+	for _, g := range groups {
+		count += len(g.Keys)
+	}
+	fmt.Printf("Found %d unused keys:\n", count)
+	for _, g := range groups {
+		fmt.Printf("  %s\n", g.label())
+		if expand && g.Prefix != "" {
+			for _, k := range g.Keys {
+				fmt.Printf("    %s\n", k)
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRollUpUnused(t *testing.T) {
+	keys := map[string]string{
+		"snapshots.title":         "Snapshots",
+		"snapshots.dialog.ok":     "OK",
+		"snapshots.dialog.cancel": "Cancel",
+		"generic.ok":              "OK",
+		"generic.cancel":          "Cancel",
+		"tray.menu.quit":          "Quit",
+		"tray.menu.show":          "Show",
+		"tray.status":             "Running",
+		"single.only":             "Only",
+	}
+	unused := []string{
+		"generic.cancel",
+		"single.only",
+		"snapshots.dialog.cancel",
+		"snapshots.dialog.ok",
+		"snapshots.title",
+		"tray.menu.quit",
+		"tray.menu.show",
+	}
+
+	got := rollUpUnused(keys, unused)
+	want := []unusedGroup{
+		{Keys: []string{"generic.cancel"}},
+		{Keys: []string{"single.only"}},
+		{Prefix: "snapshots", Keys: []string{"snapshots.dialog.cancel", "snapshots.dialog.ok", "snapshots.title"}},
+		{Prefix: "tray.menu", Keys: []string{"tray.menu.quit", "tray.menu.show"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	if l := got[2].label(); l != "snapshots.* (3 keys)" {
+		t.Errorf("label = %q", l)
+	}
+	if l := got[0].label(); l != "generic.cancel" {
+		t.Errorf("label = %q", l)
+	}
+}
+
+func TestTriageUnused(t *testing.T) {
+	keys := map[string]string{
+		"a.one":   "One",
+		"a.two":   "Two",
+		"a.three": "Three",
+		"a.four":  "Four",
+	}
+	unused := []string{"a.four", "a.one", "a.three", "a.two"}
+
+	// "x" is invalid and re-prompts; "q" stops before a.two.
+	in := strings.NewReader("d\nx\ns\nD\nq\n")
+	var out strings.Builder
+	got, err := triageUnused(keys, unused, in, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{"a.four": true, "a.three": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !strings.Contains(out.String(), "[1/4] a.four\n  Four") {
+		t.Errorf("prompt missing key and value:\n%s", out.String())
+	}
+
+	// End of input keeps earlier decisions.
+	got, err = triageUnused(keys, unused, strings.NewReader("d\n"), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, map[string]bool{"a.four": true}) {
+		t.Errorf("after EOF got %v", got)
+	}
+}
+
+func TestReportUnusedWithLines(t *testing.T) {
+	dir := writeCheckFixture(t)
+
+	out, err := captureStdout(t, func() error {
+		return reportUnused(dir, "text", unusedOptions{withLines: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "  nav.about  " + translationsDir + "/en-us.yaml:3\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected %q in output, got:\n%s", want, out)
+	}
+}
+
+func TestGroupByTopLevel(t *testing.T) {
+	unused := []string{"about.title", "nav.help", "tableHeaders.age", "tableHeaders.name", "tableHeaders.size", "tray.quit", "tray.status"}
+	got := groupByTopLevel(unused)
+	want := []keyGroup{
+		{Name: "tableHeaders", Keys: []string{"tableHeaders.age", "tableHeaders.name", "tableHeaders.size"}},
+		{Name: "tray", Keys: []string{"tray.quit", "tray.status"}},
+		{Name: "about", Keys: []string{"about.title"}},
+		{Name: "nav", Keys: []string{"nav.help"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestOutputKeyGroups(t *testing.T) {
+	groups := groupByTopLevel([]string{"tray.quit", "tray.status", "nav.help"})
+
+	out, err := captureStdout(t, func() error {
+		return outputKeyGroups(groups, "text", false, "unused keys")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Found 3 unused keys in 2 groups:\n  tray (2)\n    tray.quit\n    tray.status\n  nav (1)\n    nav.help\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+
+	// --summary keeps only the counts.
+	out, err = captureStdout(t, func() error {
+		return outputKeyGroups(groups, "text", true, "unused keys")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "Found 3 unused keys in 2 groups:\n  tray (2)\n  nav (1)\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code to check (default: every YAML translation file except en-us)")
+	format := fs.String("format", "text", "Output format: text, json")
+	fs.Parse(args)
+
+	if err := checkFormat(*format, "text", "json"); err != nil {
+		return err
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportValidate(root, *locale, *format)
+}
+
+// typedScalarHit is a locale value that YAML reads as a boolean, null,
+// number, or timestamp where en-us.yaml has a string, such as a
+// hand-written "label: yes" meant as the word.
+type typedScalarHit struct {
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	Key   string `json:"key"`
+	Value string `json:"value"` // as written in the file
+	Type  string `json:"type"`  // "bool", "null", "int", "float", or "timestamp"
+	Fix   string `json:"fix"`   // the value quoted so it reads as a string
+}
+
+// typedScalarTags are the YAML tags a plain scalar may resolve to instead
+// of a string.
+var typedScalarTags = map[string]bool{
+	"!!bool":      true,
+	"!!null":      true,
+	"!!int":       true,
+	"!!float":     true,
+	"!!timestamp": true,
+}
+
+// reportValidate re-parses locale files and lists values that YAML does
+// not read as strings although the English value is one. loadYAMLFlat
+// turns such values into their canonical form ("True" becomes "true",
+// "1.50" becomes "1.5"), so the translation silently changes.
+func reportValidate(root, locale, format string) error {
+	enPath := translationsPath(root, "en-us.yaml")
+	enDefs, err := loadYAMLFlatWithLines(enPath)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	if locale != "" {
+		path, err := findLocaleFile(root, locale)
+		if err != nil {
+			return err
+		}
+		paths = []string{path}
+	} else {
+		all, err := findTranslationFiles(root)
+		if err != nil {
+			return err
+		}
+		for _, path := range all {
+			// JSON has no unquoted strings, so its typed values are meant.
+			if path != enPath && !isJSONFile(path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	var hits []typedScalarHit
+	for _, path := range paths {
+		defs, err := loadYAMLFlatWithLines(path)
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(root, path)
+		for _, h := range findTypedScalars(defs, enDefs) {
+			h.File = relPath
+			hits = append(hits, h)
+		}
+	}
+
+	if format == "json" {
+		if hits == nil {
+			hits = []typedScalarHit{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No unquoted non-string values found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d values that YAML does not read as strings:\n", len(hits))
+	for _, h := range hits {
+		fmt.Printf("  %s:%d: %s: %s is a %s; write %s\n", h.File, h.Line, h.Key, h.Value, h.Type, h.Fix)
+	}
+	return nil
+}
+
+// findTypedScalars returns, sorted by key, the values in defs that resolve
+// to a non-string type while the same key in enDefs is a string. Empty
+// values are left to the empty report.
+func findTypedScalars(defs, enDefs map[string]keyDefinition) []typedScalarHit {
+	var hits []typedScalarHit
+	for _, k := range slices.Sorted(maps.Keys(defs)) {
+		d := defs[k]
+		en, found := enDefs[k]
+		if !found || en.Tag != "!!str" || d.Value == "" || !typedScalarTags[d.Tag] {
+			continue
+		}
+		hits = append(hits, typedScalarHit{
+			Line:  d.Line,
+			Key:   k,
+			Value: d.Value,
+			Type:  strings.TrimPrefix(d.Tag, "!!"),
+			Fix:   yamlScalar(d.Value),
+		})
+	}
+	return hits
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReportValidate(t *testing.T) {
+	root := t.TempDir()
+	transDir := filepath.Join(root, "pkg", "rancher-desktop", "assets", "translations")
+	os.MkdirAll(transDir, 0755)
+	en := "active: Active\nanswer:\n  yes: Yes\n  no: No\n  none: None\nversion: Version 1.50\nsize: 3\ndate: Date\nquoted: Quoted\nempty: Empty\n"
+	de := "active: True\nanswer:\n  yes: yes\n  no: Nein\n  none: ~\nversion: 1.50\nsize: 3\ndate: 2024-01-02\nquoted: 'true'\nempty:\n"
+	os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte(en), 0644)
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte(de), 0644)
+
+	out, err := captureStdout(t, func() error {
+		return reportValidate(root, "", "json")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hits []typedScalarHit
+	if err := json.Unmarshal([]byte(out), &hits); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	// "yes" is a string in YAML 1.2, and size is a number in en-us.yaml too.
+	var got []string
+	for _, h := range hits {
+		got = append(got, h.Key+" "+h.Type+" "+h.Fix)
+	}
+	want := []string{
+		"active bool \"True\"",
+		"answer.none null \"~\"",
+		"date timestamp \"2024-01-02\"",
+		"version float \"1.50\"",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if len(hits) > 0 && (hits[0].File != filepath.Join("pkg", "rancher-desktop", "assets", "translations", "de.yaml") || hits[0].Line != 1) {
+		t.Errorf("location = %s:%d", hits[0].File, hits[0].Line)
+	}
+}
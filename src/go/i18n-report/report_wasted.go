@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+func runWasted(args []string) error {
+	fs := flag.NewFlagSet("wasted", flag.ExitOnError)
+	locale := fs.String("locale", "", "Target locale code (required)")
+	format := fs.String("format", "text", "Output format: text, json, yaml")
+	scanOpts := scanFlags(fs)
+	source := sourceLocaleFlag(fs)
+	fs.Parse(args)
+
+	if err := checkFormat(*format, "text", "json", "yaml"); err != nil {
+		return err
+	}
+	if *locale == "" {
+		return fmt.Errorf("--locale is required")
+	}
+
+	scan, err := scanOpts()
+	if err != nil {
+		return err
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportWasted(root, *source, *locale, *format, scan)
+}
+
+// reportWasted lists the keys a locale translates that no source file
+// references: translation work spent on strings that never show. Unlike
+// stale keys, these still exist in en-us.yaml, so they are pruned from
+// the locale together with the unused English keys.
+func reportWasted(root, source, locale, format string, opts scanOptions) error {
+	enPath, err := sourceLocalePath(root, source)
+	if err != nil {
+		return err
+	}
+	enKeys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return err
+	}
+	localePath, err := findLocaleFile(root, locale)
+	if err != nil {
+		return err
+	}
+	localeKeys, err := loadYAMLFlat(localePath)
+	if err != nil {
+		return err
+	}
+
+	refs, err := findKeyReferences(root, enKeys, opts)
+	if err != nil {
+		return err
+	}
+	return outputStrings(wastedKeys(unusedKeys(enKeys, refs), localeKeys), format, "unused keys translated in "+locale)
+}
+
+// wastedKeys returns the unused keys that the locale defines, in order.
+func wastedKeys(unused []string, localeKeys map[string]string) []string {
+	var wasted []string
+	for _, k := range unused {
+		if _, found := localeKeys[k]; found {
+			wasted = append(wasted, k)
+		}
+	}
+	return wasted
+}
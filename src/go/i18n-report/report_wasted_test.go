@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReportWasted(t *testing.T) {
+	root := writeCheckFixture(t)
+	transDir := filepath.Join(root, "pkg", "rancher-desktop", "assets", "translations")
+	os.WriteFile(filepath.Join(transDir, "fr.yaml"), []byte("nav:\n  home: Accueil\n"), 0644)
+
+	// en-us has nav.home (used) and nav.about (unused); de translates
+	// nav.home and the stale nav.old, fr only nav.home.
+	os.WriteFile(filepath.Join(transDir, "de.yaml"), []byte("nav:\n  home: Startseite\n  about: Über\n  old: Alt\n"), 0644)
+
+	for locale, want := range map[string][]string{"de": {"nav.about"}, "fr": {}} {
+		out, err := captureStdout(t, func() error {
+			return reportWasted(root, "", locale, "json", scanOptions{})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("%s: got %v, want %v", locale, got, want)
+		}
+	}
+}
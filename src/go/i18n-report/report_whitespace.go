@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+func runWhitespace(args []string) error {
+	fs := flag.NewFlagSet("whitespace", flag.ExitOnError)
+	locale := fs.String("locale", "", "Locale code to check (default: every translation file, including en-us)")
+	format := fs.String("format", "text", "Output format: text, json")
+	fix := fs.Bool("fix", false, "Trim the reported whitespace in translated locales, rewriting the files (en-us is never changed; "+
+		"values spanning several lines, such as block scalars, are only listed for fixing by hand)")
+	fs.Parse(args)
+
+	if err := checkFormat(*format, "text", "json"); err != nil {
+		return err
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return err
+	}
+	return reportWhitespace(root, *locale, *format, *fix)
+}
+
+// whitespaceHit is a value with stray whitespace.
+type whitespaceHit struct {
+	File    string   `json:"file"`
+	Key     string   `json:"key"`
+	Issues  []string `json:"issues"`
+	Visible string   `json:"visible"` // the value with whitespace made visible
+}
+
+// reportWhitespace lists values with leading or trailing whitespace or
+// internal double spaces, in one locale file or in every translation
+// file. With fix, the reported issues are cleaned up in place; the source
+// locale is only reported, since its whitespace is often deliberate.
+func reportWhitespace(root, locale, format string, fix bool) error {
+	enPath := translationsPath(root, "en-us.yaml")
+	enKeys, err := loadYAMLFlat(enPath)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	if locale != "" {
+		path, err := findLocaleFile(root, locale)
+		if err != nil {
+			return err
+		}
+		paths = []string{path}
+	} else {
+		paths, err = findTranslationFiles(root)
+		if err != nil {
+			return err
+		}
+	}
+
+	var hits []whitespaceHit
+	for _, path := range paths {
+		keys, err := loadYAMLFlat(path)
+		if err != nil {
+			return err
+		}
+		reference := enKeys
+		if path == enPath {
+			reference = nil
+		}
+		relPath, _ := filepath.Rel(root, path)
+		fileHits := findWhitespaceIssues(keys, reference)
+		for i := range fileHits {
+			fileHits[i].File = relPath
+		}
+		hits = append(hits, fileHits...)
+
+		if fix && len(fileHits) > 0 && path == enPath {
+			fmt.Fprintf(os.Stderr, "Not fixing %s: edit the source locale by hand\n", relPath)
+		} else if fix && len(fileHits) > 0 {
+			fixed, err := fixWhitespace(path, fileHits)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Fixed %d values in %s\n", fixed, relPath)
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hits)
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No whitespace issues found.")
+		return nil
+	}
+
+	fmt.Printf("Found %d values with stray whitespace:\n", len(hits))
+	for _, h := range hits {
+		fmt.Printf("  %s: %s (%s)\n    %s\n", h.File, h.Key, strings.Join(h.Issues, ", "), h.Visible)
+	}
+	return nil
+}
+
+// doubleSpacePattern matches two or more spaces between words.
+var doubleSpacePattern = regexp.MustCompile(`(\S) {2,}`)
+
+// whitespaceIssues names the stray whitespace in a value: "leading",
+// "trailing", and "double space". The final newline a block scalar
+// ("|" or ">") ends with is not stray.
+func whitespaceIssues(value string) []string {
+	value = strings.TrimSuffix(value, "\n")
+	var issues []string
+	if value != strings.TrimLeftFunc(value, unicode.IsSpace) {
+		issues = append(issues, "leading")
+	}
+	if value != strings.TrimRightFunc(value, unicode.IsSpace) {
+		issues = append(issues, "trailing")
+	}
+	if doubleSpacePattern.MatchString(strings.TrimSpace(value)) {
+		issues = append(issues, "double space")
+	}
+	return issues
+}
+
+// findWhitespaceIssues returns the keys whose value has stray whitespace,
+// sorted by key. When enKeys is non-nil, issues the English value shares
+// are taken as intentional and skipped; blank values are left to empty.
+func findWhitespaceIssues(keys, enKeys map[string]string) []whitespaceHit {
+	var hits []whitespaceHit
+	for _, k := range sortedKeys(keys) {
+		v := keys[k]
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		var issues []string
+		for _, issue := range whitespaceIssues(v) {
+			if enValue, found := enKeys[k]; found && slices.Contains(whitespaceIssues(enValue), issue) {
+				continue
+			}
+			issues = append(issues, issue)
+		}
+		if len(issues) > 0 {
+			hits = append(hits, whitespaceHit{Key: k, Issues: issues, Visible: visibleWhitespace(v)})
+		}
+	}
+	return hits
+}
+
+// visibleWhitespace shows spaces as "·", tabs as "→", and newlines as
+// "↵", so stray whitespace can be seen in terminal output.
+func visibleWhitespace(s string) string {
+	return strings.NewReplacer(" ", "·", "\t", "→", "\n", "↵").Replace(s)
+}
+
+// trimWhitespace removes the given issues from a value: leading or
+// trailing whitespace, and runs of spaces between words, which become
+// one. Issues that are not named are left alone, so whitespace the
+// English value shares survives. A block scalar keeps its final newline.
+func trimWhitespace(s string, issues []string) string {
+	trimmed, block := strings.CutSuffix(s, "\n")
+	if slices.Contains(issues, "leading") {
+		trimmed = strings.TrimLeftFunc(trimmed, unicode.IsSpace)
+	}
+	if slices.Contains(issues, "trailing") {
+		trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	}
+	if slices.Contains(issues, "double space") {
+		trimmed = doubleSpacePattern.ReplaceAllString(trimmed, "$1 ")
+	}
+	if block {
+		trimmed += "\n"
+	}
+	return trimmed
+}
+
+// fixWhitespace trims the values of the given hits in a translation file
+// and returns how many were fixed. Like remove, it leaves the rest of the
+// file alone: only the line holding each value is rewritten, so comments,
+// blank lines, and the style of other values are kept. Values spanning
+// several lines, such as block scalars, are reported for fixing by hand.
+func fixWhitespace(path string, hits []whitespaceHit) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return 0, nil
+	}
+	nodes := make(map[string]*yaml.Node)
+	collectValueNodes("", doc.Content[0], nodes)
+
+	lines := strings.Split(string(data), "\n")
+	fixed := 0
+	// Edit right to left so that earlier columns on a line stay valid.
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := nodePosition(nodes[hits[i].Key]), nodePosition(nodes[hits[j].Key])
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		return a[1] > b[1]
+	})
+	for _, h := range hits {
+		node, ok := nodes[h.Key]
+		if ok && replaceScalarLine(lines, node, trimWhitespace(node.Value, h.Issues), isJSONFile(path)) {
+			fixed++
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s spans several lines; fix it by hand\n", path, h.Key)
+	}
+	if fixed == 0 {
+		return 0, nil
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return 0, fmt.Errorf("writing %s: %w", path, err)
+	}
+	return fixed, nil
+}
+
+// nodePosition returns the line and column of node, or zeros for nil.
+func nodePosition(node *yaml.Node) [2]int {
+	if node == nil {
+		return [2]int{}
+	}
+	return [2]int{node.Line, node.Column}
+}
+
+// replaceScalarLine replaces the text of a single-line scalar node in
+// lines with value, keeping the key before it and any comment or JSON
+// comma after it. It reports false, changing nothing, when the node's
+// source text cannot be found on its line.
+func replaceScalarLine(lines []string, node *yaml.Node, value string, jsonFile bool) bool {
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 || node.Line < 1 || node.Line > len(lines) {
+		return false
+	}
+	line := []rune(lines[node.Line-1])
+	start := node.Column - 1
+	if start < 0 || start > len(line) {
+		return false
+	}
+	rest := string(line[start:])
+	text := rest
+	if node.LineComment != "" {
+		idx := strings.LastIndex(text, node.LineComment)
+		if idx < 0 {
+			return false
+		}
+		text = text[:idx]
+	}
+	text = strings.TrimRight(text, " \t")
+	if jsonFile {
+		text = strings.TrimSuffix(text, ",")
+	}
+	// Make sure text is the whole scalar, not its first line.
+	var parsed string
+	if err := yaml.Unmarshal([]byte(text), &parsed); err != nil || parsed != node.Value {
+		return false
+	}
+
+	scalar := yamlScalar(value)
+	if jsonFile {
+		scalar = jsonString(value)
+	}
+	if strings.Contains(scalar, "\n") {
+		return false
+	}
+	lines[node.Line-1] = string(line[:start]) + scalar + rest[len(text):]
+	return true
+}
+
+// collectValueNodes records the scalar node of every leaf key under node,
+// keyed like collectKeyDefinitions.
+func collectValueNodes(prefix string, node *yaml.Node, nodes map[string]*yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for _, p := range mappingPairs(node) {
+			key := p.key.Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			collectValueNodes(key, p.value, nodes)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			collectValueNodes(prefix+"."+strconv.Itoa(i), resolveAlias(item), nodes)
+		}
+	case yaml.ScalarNode:
+		nodes[prefix] = node
+	}
+}
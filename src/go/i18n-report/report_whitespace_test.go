@@ -0,0 +1,196 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWhitespaceIssues(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"Save", nil},
+		{" Save", []string{"leading"}},
+		{"Save\t", []string{"trailing"}},
+		{"Save  all", []string{"double space"}},
+		{"  Save  all ", []string{"leading", "trailing", "double space"}},
+		{"Line one\nLine two\n", nil},
+		{"Line one\n  indented\n", nil},
+	}
+	for _, tt := range tests {
+		got := whitespaceIssues(tt.value)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("whitespaceIssues(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFindWhitespaceIssues(t *testing.T) {
+	keys := map[string]string{
+		"action.save":   "Speichern ",
+		"action.cancel": "Abbrechen",
+		"action.reset":  "   ",
+		"msg.prefix":    "Siehe ",
+	}
+	enKeys := map[string]string{
+		"action.save":   "Save",
+		"action.cancel": "Cancel",
+		"action.reset":  "Reset",
+		"msg.prefix":    "See ",
+	}
+	got := findWhitespaceIssues(keys, enKeys)
+	want := []whitespaceHit{{Key: "action.save", Issues: []string{"trailing"}, Visible: "Speichern·"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFixWhitespace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.yaml")
+	de := `nav:
+  # Shown in the header.
+  home: " Startseite  "
+  about: Über  uns
+`
+	if err := os.WriteFile(path, []byte(de), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := loadYAMLFlat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fixWhitespace(path, findWhitespaceIssues(keys, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want := `nav:
+  # Shown in the header.
+  home: Startseite
+  about: Über uns
+`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFixWhitespaceKeepsLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.yaml")
+	de := `nav:
+  home: 'Start  seite '  # header
+  when: ' um {time}  '
+
+images:
+  deleteError: |-
+    Fehler: {error}
+  tag: "Tag  "
+`
+	en := map[string]string{
+		"nav.home":           "Home",
+		"nav.when":           " at {time}",
+		"images.deleteError": "Error:\n {error}",
+		"images.tag":         "Tag",
+	}
+	if err := os.WriteFile(path, []byte(de), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := loadYAMLFlat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed, err := fixWhitespace(path, findWhitespaceIssues(keys, en))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixed != 3 {
+		t.Errorf("fixed = %d, want 3", fixed)
+	}
+
+	// The leading space en-us shares is kept, and the blank line, the
+	// comment and the untouched block scalar survive.
+	got, _ := os.ReadFile(path)
+	want := `nav:
+  home: Start seite  # header
+  when: ' um {time}'
+
+images:
+  deleteError: |-
+    Fehler: {error}
+  tag: Tag
+`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFixWhitespaceLeavesMultiLineValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.yaml")
+	de := `images:
+  deleteError: |
+    Fehler:  {error}
+  hint: >
+    Siehe  die
+    Dokumentation
+`
+	if err := os.WriteFile(path, []byte(de), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := loadYAMLFlat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hits := findWhitespaceIssues(keys, nil)
+	if len(hits) != 2 {
+		t.Fatalf("hits = %+v, want both block scalars", hits)
+	}
+
+	// Block scalars are only listed: --fix edits single lines.
+	var fixed int
+	stderr, err := captureStderr(t, func() (err error) {
+		fixed, err = fixWhitespace(path, hits)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixed != 0 {
+		t.Errorf("fixed = %d, want 0", fixed)
+	}
+	for _, key := range []string{"images.deleteError", "images.hint"} {
+		if !strings.Contains(stderr, key+" spans several lines; fix it by hand") {
+			t.Errorf("no fix-by-hand warning for %s in %q", key, stderr)
+		}
+	}
+	if got, _ := os.ReadFile(path); string(got) != de {
+		t.Errorf("file was rewritten:\n%s", got)
+	}
+}
+
+func TestReportWhitespaceFixSkipsSourceLocale(t *testing.T) {
+	dir := t.TempDir()
+	transDir := filepath.Join(dir, "pkg", "rancher-desktop", "assets", "translations")
+	if err := os.MkdirAll(transDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	en := "nav:\n  home: 'Home '\n"
+	if err := os.WriteFile(filepath.Join(transDir, "en-us.yaml"), []byte(en), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := captureStdout(t, func() error {
+		return reportWhitespace(dir, "", "text", true)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := os.ReadFile(filepath.Join(transDir, "en-us.yaml"))
+	if string(got) != en {
+		t.Errorf("en-us.yaml was rewritten:\n%s", got)
+	}
+}
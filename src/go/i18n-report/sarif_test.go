@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteUntranslatedSARIF(t *testing.T) {
+	hits := []untranslatedHit{
+		{File: "pkg/rancher-desktop/pages/Home.vue", Line: 12, Context: `label="Save changes"`},
+	}
+
+	var buf bytes.Buffer
+	if err := writeUntranslatedSARIF(&buf, hits); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version=%q runs=%d", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "i18n-report" || run.Tool.Driver.Version == "" {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	if len(run.Results) != 1 {
+		t.Fatalf("got %d results, want 1", len(run.Results))
+	}
+	r := run.Results[0]
+	loc := r.Locations[0].PhysicalLocation
+	if r.RuleID != "hardcoded-string" || r.Message.Text != `label="Save changes"` ||
+		loc.ArtifactLocation.URI != "pkg/rancher-desktop/pages/Home.vue" || loc.Region.StartLine != 12 {
+		t.Errorf("result = %+v", r)
+	}
+}
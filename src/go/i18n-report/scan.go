@@ -1,9 +1,15 @@
 package main
 
 import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -20,12 +26,21 @@ type dynamicKeyRef struct {
 	Pattern  string         // human-readable: "prefix.{}.suffix"
 	Regex    *regexp.Regexp // compiled regex for matching keys
 	Ref      keyReference   // source location
+	KeyArg   bool           // literal is the key of a t() call or key property
 }
 
 // Patterns for finding translation key references in source code.
 var (
-	// t('...'), t("..."), t(`...`), also this.t(...) and $t(...)
-	keyPattern = regexp.MustCompile(`(?:^|[^a-zA-Z])t\(['"\x60]([a-zA-Z0-9_.]+)['"\x60]`)
+	// t('...'), t("..."), t(`...`), also this.t(...) and $t(...), and the
+	// vue-i18n pluralization forms tc(...), this.tc(...) and $tc(...).
+	// Whitespace inside the quotes is tolerated so that padded keys still
+	// count as references; see paddedKeyPattern.
+	keyPattern = regexp.MustCompile(`(?:^|[^a-zA-Z])tc?\(['"\x60]\s*([a-zA-Z0-9_.]+)\s*['"\x60]`)
+	// t() calls whose key literal has leading or trailing whitespace, which
+	// never matches the stored key at runtime.
+	paddedKeyPattern = regexp.MustCompile(`(?:^|[^a-zA-Z])tc?\(['"\x60](\s+[a-zA-Z0-9_.]+\s*|[a-zA-Z0-9_.]+\s+)['"\x60]`)
+	// i18next-style t('namespace:dotted.key') calls (see --namespaces).
+	namespacedKeyPattern = regexp.MustCompile(`(?:^|[^a-zA-Z])t\(['"\x60]\s*([a-zA-Z0-9_]+:[a-zA-Z0-9_.]+)\s*['"\x60]`)
 	// titleKey/descriptionKey/labelKey properties with string literal values.
 	keyPropPattern = regexp.MustCompile(`(?:titleKey|descriptionKey|labelKey):\s*['"]([a-zA-Z0-9_.]+)['"]`)
 	// Lines containing a Key property may use ternaries; extract all dotted keys.
@@ -47,26 +62,165 @@ var (
 	// Matches backtick strings containing at least one dot and one ${...}
 	// interpolation, with a key-like prefix (e.g., `prefix.${var}.suffix`).
 	dynamicKeyLiteral = regexp.MustCompile("\x60([a-zA-Z][a-zA-Z0-9]*\\.[^\x60]*\\$\\{[^}]+\\}[^\x60]*)\x60")
+	// Text immediately before a template literal that makes it the key
+	// argument of t()/tc(), a *Key property, or a -key attribute.
+	dynamicKeyContext = regexp.MustCompile(`(?:(?:^|[^a-zA-Z])tc?\(\s*|(?:titleKey|descriptionKey|labelKey):\s*|[a-z]+-key="\s*)$`)
 
-	// Splits a template string on ${...} interpolations.
-	interpolationSplit = regexp.MustCompile(`\$\{[^}]+\}`)
+	// File-level string constants, e.g. const baseKey = 'containerEngine.options'.
+	stringConstPattern = regexp.MustCompile(`\bconst\s+(\w+)\s*=\s*['"]([a-zA-Z0-9_.]+)['"]`)
+	// The start of a string constant declaration that continues on the
+	// next line, e.g. "const baseKey =".
+	unfinishedConstPattern = regexp.MustCompile(`\bconst(?:\s+\w+)?\s*=?\s*$`)
+	// t() calls concatenating an identifier with a literal suffix,
+	// e.g. t(baseKey + '.label').
+	constConcatPattern = regexp.MustCompile(`(?:^|[^a-zA-Z])t\(\s*(\w+)\s*\+\s*['"]([a-zA-Z0-9_.]*)['"]\s*\)`)
+
+	// Quoted dotted strings inside a tagged template (see --scan-tag).
+	taggedKeyLiteral = regexp.MustCompile(`['"]([a-zA-Z][a-zA-Z0-9_-]*(?:\.[a-zA-Z0-9_-]+)+)['"]`)
 )
 
 // segmentWildcard matches a single key segment produced by an interpolation.
 const segmentWildcard = `[a-zA-Z0-9_-]+`
 
+// multiSegmentWildcard matches one or more key segments, for interpolations
+// that expand to dotted values (e.g. `errors.${code}` with code
+// "network.timeout").
+const multiSegmentWildcard = `[a-zA-Z0-9_-]+(?:\.[a-zA-Z0-9_-]+)*`
+
+// scanOptions controls how source scanning resolves key references.
+type scanOptions struct {
+	// greedyDynamic lets each interpolation in a dynamic key pattern match
+	// several dotted segments instead of exactly one.
+	greedyDynamic bool
+	// allowPrefixes marks keys under these prefixes as used, for keys
+	// assembled at runtime in ways the scanner cannot see.
+	allowPrefixes []allowedPrefix
+	// scanTags names tagged templates (e.g. gql) whose quoted string
+	// literals are checked against en-us.yaml keys.
+	scanTags []string
+	// excludeTests drops references from *.spec.* and *.test.* files, so
+	// keys used only by tests count as unused.
+	excludeTests bool
+	// namespaces accepts i18next-style "namespace:dotted.key" keys,
+	// mapping the namespace to a top-level YAML node.
+	namespaces bool
+	// onlyFiles, when non-nil, limits scanning to these absolute paths
+	// (see --since).
+	onlyFiles map[string]bool
+	// exclude lists glob patterns of source paths to skip, in addition
+	// to the built-in skipped directories (see --exclude).
+	exclude []string
+}
+
+// allowedPrefix is a key prefix read from a --dynamic-allow file.
+type allowedPrefix struct {
+	Prefix string
+	Ref    keyReference // location in the allowlist file
+}
+
+// matches reports whether key falls under the prefix. A prefix ending in
+// "." matches any key starting with it; otherwise it matches the key itself
+// or keys nested under it, so "errors.api" does not match "errors.apiVersion".
+func (a allowedPrefix) matches(key string) bool {
+	if strings.HasSuffix(a.Prefix, ".") {
+		return strings.HasPrefix(key, a.Prefix)
+	}
+	return key == a.Prefix || strings.HasPrefix(key, a.Prefix+".")
+}
+
+// scanFlags registers the flags shared by subcommands that resolve key
+// references. The returned function builds scanOptions after fs.Parse.
+func scanFlags(fs *flag.FlagSet) func() (scanOptions, error) {
+	greedy := fs.Bool("greedy-dynamic", false, "Let dynamic key interpolations match multiple dotted segments")
+	allow := fs.String("dynamic-allow", "", "File of key prefixes (one per line, # comments) to treat as used; "+
+		"applied in addition to literal references and detected dynamic patterns")
+	tags := fs.String("scan-tag", "", "Comma-separated tagged template names (e.g. gql) whose string literals may be keys")
+	excludeTests := fs.Bool("exclude-tests", false, "Ignore references from *.spec.* and *.test.* files")
+	namespaces := fs.Bool("namespaces", false, "Accept i18next-style t('namespace:dotted.key') keys, with the namespace as the top-level YAML node")
+	exclude := excludeFlag(fs)
+	return func() (scanOptions, error) {
+		opts := scanOptions{greedyDynamic: *greedy, excludeTests: *excludeTests, namespaces: *namespaces, exclude: *exclude}
+		for _, tag := range strings.Split(*tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				opts.scanTags = append(opts.scanTags, tag)
+			}
+		}
+		if *allow != "" {
+			prefixes, err := loadPrefixAllowlist(*allow)
+			if err != nil {
+				return opts, err
+			}
+			opts.allowPrefixes = prefixes
+		}
+		return opts, nil
+	}
+}
+
+// globList is a repeatable flag collecting glob patterns.
+type globList []string
+
+func (g *globList) String() string {
+	return strings.Join(*g, ",")
+}
+
+func (g *globList) Set(value string) error {
+	if _, err := path.Match(value, ""); err != nil {
+		return fmt.Errorf("invalid glob %q: %w", value, err)
+	}
+	*g = append(*g, value)
+	return nil
+}
+
+// excludeFlag registers the repeatable --exclude flag.
+func excludeFlag(fs *flag.FlagSet) *globList {
+	var exclude globList
+	fs.Var(&exclude, "exclude", "Skip source files and directories matching this glob, relative to the repository root "+
+		"(e.g. pkg/rancher-desktop/generated or **/*.generated.ts); may be repeated")
+	return &exclude
+}
+
+// loadPrefixAllowlist reads key prefixes from a file, one per line.
+// Blank lines and lines starting with "#" are ignored, as is anything
+// after a "#" on a prefix line.
+func loadPrefixAllowlist(path string) ([]allowedPrefix, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var prefixes []allowedPrefix
+	for i, line := range strings.Split(string(data), "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		prefixes = append(prefixes, allowedPrefix{
+			Prefix: line,
+			Ref:    keyReference{File: path, Line: i + 1},
+		})
+	}
+	return prefixes, nil
+}
+
 // templateToKeyRegex converts a template literal with ${...} interpolations
 // into a regex that matches translation keys. Static parts become literal
-// matches; each interpolation becomes a wildcard matching one key segment.
-func templateToKeyRegex(template string) *regexp.Regexp {
-	parts := interpolationSplit.Split(template, -1)
+// matches; each interpolation becomes a wildcard matching one key segment,
+// or one or more segments when greedy is true.
+func templateToKeyRegex(template string, greedy bool) *regexp.Regexp {
+	parts := splitInterpolations(template)
+	wildcard := segmentWildcard
+	if greedy {
+		wildcard = multiSegmentWildcard
+	}
 
 	var sb strings.Builder
 	sb.WriteString("^")
 	for i, part := range parts {
 		sb.WriteString(regexp.QuoteMeta(part))
 		if i < len(parts)-1 {
-			sb.WriteString(segmentWildcard)
+			sb.WriteString(wildcard)
 		}
 	}
 	sb.WriteString("$")
@@ -81,18 +235,68 @@ func templateToKeyRegex(template string) *regexp.Regexp {
 // templateToHumanPattern converts a template literal to a readable pattern
 // by replacing ${...} interpolations with {}.
 func templateToHumanPattern(template string) string {
-	return interpolationSplit.ReplaceAllString(template, "{}")
+	return strings.Join(splitInterpolations(template), "{}")
+}
+
+// splitInterpolations splits a template literal on its ${...}
+// interpolations and returns the static parts, one more than there are
+// interpolations. An interpolation ends at the "}" that balances its
+// opening brace, so expressions containing object literals, nested
+// templates, or quoted braces (`x.${ m['}'] }.y`) are spanned whole.
+// An unterminated interpolation is kept as static text.
+func splitInterpolations(template string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i+1 < len(template); i++ {
+		if template[i] != '$' || template[i+1] != '{' {
+			continue
+		}
+		end := interpolationEnd(template, i+2)
+		if end < 0 {
+			break
+		}
+		parts = append(parts, template[start:i])
+		start = end + 1
+		i = end
+	}
+	return append(parts, template[start:])
+}
+
+// interpolationEnd returns the index of the "}" closing an interpolation
+// whose expression starts at pos, or -1 if it is not closed. Braces inside
+// quoted strings are skipped.
+func interpolationEnd(s string, pos int) int {
+	depth := 1
+	for i := pos; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '"', '\x60':
+			for i++; i < len(s) && s[i] != c; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
 // extractDynamicPatterns finds dynamic template literal key patterns in a line.
-func extractDynamicPatterns(line string, ref keyReference) []dynamicKeyRef {
+// See templateToKeyRegex for the meaning of greedy.
+func extractDynamicPatterns(line string, ref keyReference, greedy bool) []dynamicKeyRef {
 	var dynamics []dynamicKeyRef
-	for _, m := range dynamicKeyLiteral.FindAllStringSubmatch(line, -1) {
-		template := m[1]
+	for _, m := range dynamicKeyLiteral.FindAllStringSubmatchIndex(line, -1) {
+		template := line[m[2]:m[3]]
 		if !strings.Contains(template, "${") {
 			continue
 		}
-		re := templateToKeyRegex(template)
+		re := templateToKeyRegex(template, greedy)
 		if re == nil {
 			continue
 		}
@@ -101,26 +305,38 @@ func extractDynamicPatterns(line string, ref keyReference) []dynamicKeyRef {
 			Pattern:  templateToHumanPattern(template),
 			Regex:    re,
 			Ref:      ref,
+			KeyArg:   dynamicKeyContext.MatchString(line[:m[0]]),
 		})
 	}
 	return dynamics
 }
 
-// scanSourceFiles walks the source tree and returns file paths matching
-// the given extensions.
-func scanSourceFiles(root string, exts []string) ([]string, error) {
+// skippedDirs are never scanned for source files.
+var skippedDirs = []string{"node_modules", ".git", "dist", "vendor", "__tests__"}
+
+// scanSourceFiles walks dir and returns file paths matching the given
+// extensions. Paths matching an exclude glob are skipped along with the
+// built-in skippedDirs; globs match the path relative to the repository
+// root, as in pkg/rancher-desktop/generated, whatever dir is walked.
+func scanSourceFiles(root, dir string, exts, exclude []string) ([]string, error) {
 	var files []string
 	extSet := make(map[string]bool, len(exts))
 	for _, e := range exts {
 		extSet[e] = true
 	}
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		name := d.Name()
+		if rel, _ := filepath.Rel(root, path); path != dir && isExcluded(rel, exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if d.IsDir() {
-			if name == "node_modules" || name == ".git" || name == "dist" || name == "vendor" || name == "__tests__" {
+			if slices.Contains(skippedDirs, name) {
 				return filepath.SkipDir
 			}
 			return nil
@@ -133,89 +349,430 @@ func scanSourceFiles(root string, exts []string) ([]string, error) {
 	return files, err
 }
 
-// scanFiles reads source files and returns literal key references and
-// dynamic patterns. This shared helper avoids scanning the source tree twice.
-func scanFiles(root string, keys map[string]string) (map[string][]keyReference, []dynamicKeyRef, error) {
+// isExcluded reports whether a path relative to the repository root
+// matches one of the exclude globs. A "**/" prefix also matches at any
+// depth, so "**/generated" skips every directory named generated.
+func isExcluded(rel string, exclude []string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if rest, found := strings.CutPrefix(pattern, "**/"); found {
+			for i := 0; i < len(rel); i++ {
+				if rel[i] != '/' {
+					continue
+				}
+				if ok, _ := path.Match(rest, rel[i+1:]); ok {
+					return true
+				}
+			}
+			if ok, _ := path.Match(rest, rel); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keySourceExts are the extensions of files scanned for key references.
+var keySourceExts = []string{".vue", ".ts", ".js", ".tsx", ".jsx"}
+
+// keySourceFiles returns the source files scanned for key references:
+// everything under pkg/rancher-desktop plus root-level files such as
+// background.ts. Paths matching an exclude glob are skipped; see
+// scanSourceFiles.
+func keySourceFiles(root string, exclude []string) ([]string, error) {
 	srcDir := filepath.Join(root, "pkg", "rancher-desktop")
-	exts := []string{".vue", ".ts", ".js"}
-	files, err := scanSourceFiles(srcDir, exts)
+	files, err := scanSourceFiles(root, srcDir, keySourceExts, exclude)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Also scan root-level source files (e.g. background.ts).
-	extSet := make(map[string]bool, len(exts))
-	for _, e := range exts {
+	extSet := make(map[string]bool, len(keySourceExts))
+	for _, e := range keySourceExts {
 		extSet[e] = true
 	}
 	if entries, err := os.ReadDir(root); err == nil {
 		for _, entry := range entries {
-			if !entry.IsDir() && extSet[filepath.Ext(entry.Name())] {
+			if !entry.IsDir() && extSet[filepath.Ext(entry.Name())] && !isExcluded(entry.Name(), exclude) {
 				files = append(files, filepath.Join(root, entry.Name()))
 			}
 		}
 	}
+	return files, nil
+}
+
+// scanFiles reads source files and returns literal key references and
+// dynamic patterns. This shared helper avoids scanning the source tree twice.
+func scanFiles(root string, keys map[string]string, opts scanOptions) (map[string][]keyReference, []dynamicKeyRef, error) {
+	defer timePhase("source scan")()
+	files, err := keySourceFiles(root, opts.exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+	logf("found %d source files", len(files))
 
 	refs := make(map[string][]keyReference)
 	var dynamics []dynamicKeyRef
 
-	for _, file := range files {
-		data, err := os.ReadFile(file)
+	for i, file := range files {
+		if i > 0 && i%scanProgressInterval == 0 {
+			logf("scanned %d/%d files", i, len(files))
+		}
+		if opts.onlyFiles != nil && !opts.onlyFiles[file] {
+			continue
+		}
+		relPath, _ := filepath.Rel(root, file)
+		fileDynamics, err := scanFile(file, relPath, keys, opts, refs)
 		if err != nil {
 			continue
 		}
-		lines := strings.Split(string(data), "\n")
-		for i, line := range lines {
-			relPath, _ := filepath.Rel(root, file)
-			ref := keyReference{File: relPath, Line: i + 1}
+		dynamics = append(dynamics, fileDynamics...)
+	}
+	return refs, dynamics, nil
+}
 
-			for _, pat := range []*regexp.Regexp{keyPattern, keyPropPattern, keyAttrPattern, vtDirectivePattern} {
-				for _, m := range pat.FindAllStringSubmatch(line, -1) {
-					refs[m[1]] = append(refs[m[1]], ref)
-				}
+// maxScanLine bounds the length of a single source line. The scanner
+// buffer starts small and grows up to this size, so memory use depends
+// on the longest line rather than the size of the file.
+const maxScanLine = 16 * 1024 * 1024
+
+// newLineScanner returns a line scanner over r whose buffer can grow to
+// maxScanLine.
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScanLine)
+	return scanner
+}
+
+// scanFile streams one source file from disk, recording its literal key
+// references under relPath and returning its dynamic patterns. The file
+// is read twice, line by line: once for string constants, which may be
+// declared after their use, and once for references.
+func scanFile(path, relPath string, keys map[string]string, opts scanOptions, refs map[string][]keyReference) ([]dynamicKeyRef, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	consts, err := readStringConstants(f)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return scanLines(relPath, f, consts, keys, opts, refs)
+}
+
+// scanSource is scanFile for contents already in memory, such as a file
+// read from git history.
+func scanSource(relPath, src string, keys map[string]string, opts scanOptions, refs map[string][]keyReference) []dynamicKeyRef {
+	dynamics, _ := scanLines(relPath, strings.NewReader(src), stringConstants(src), keys, opts, refs)
+	return dynamics
+}
+
+// scanLines records the literal key references in r under relPath and
+// returns its dynamic patterns. consts holds the file's string constants.
+func scanLines(relPath string, r io.Reader, consts map[string]string, keys map[string]string, opts scanOptions, refs map[string][]keyReference) ([]dynamicKeyRef, error) {
+	var dynamics []dynamicKeyRef
+	tagged := make([]*taggedTemplateState, 0, len(opts.scanTags))
+	for _, tag := range opts.scanTags {
+		tagged = append(tagged, newTaggedTemplateState(tag))
+	}
+
+	scanner := newLineScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		ref := keyReference{File: relPath, Line: lineNum}
+
+		// Several patterns can match the same key on one line (e.g. a
+		// titleKey property is also an indirect reference); record
+		// each key at most once per line.
+		seen := make(map[string]bool)
+		addRef := func(key string) {
+			if !seen[key] {
+				seen[key] = true
+				refs[key] = append(refs[key], ref)
+			}
+		}
+
+		for _, pat := range []*regexp.Regexp{keyPattern, keyPropPattern, keyAttrPattern, vtDirectivePattern} {
+			for _, m := range pat.FindAllStringSubmatch(line, -1) {
+				addRef(m[1])
 			}
-			// Lines with key properties may use ternaries; extract all dotted keys.
-			if keyPropLine.MatchString(line) {
-				for _, m := range dottedKeyLiteral.FindAllStringSubmatch(line, -1) {
-					refs[m[1]] = append(refs[m[1]], ref)
+		}
+		if opts.namespaces {
+			for _, m := range namespacedKeyPattern.FindAllStringSubmatch(line, -1) {
+				if key, ok := namespacedKey(m[1]); ok {
+					addRef(key)
 				}
 			}
-			// Indirect key references: only count matches that exist in en-us.yaml.
-			for _, m := range indirectKeyPattern.FindAllStringSubmatch(line, -1) {
-				if _, exists := keys[m[1]]; exists {
-					refs[m[1]] = append(refs[m[1]], ref)
+		}
+		// t(constant + '.suffix') with a constant defined in this file.
+		for _, m := range constConcatPattern.FindAllStringSubmatch(line, -1) {
+			if value, ok := consts[m[1]]; ok {
+				addRef(value + m[2])
+			}
+		}
+		// Lines with key properties may use ternaries; extract all dotted keys.
+		if keyPropLine.MatchString(line) {
+			for _, m := range dottedKeyLiteral.FindAllStringSubmatch(line, -1) {
+				addRef(m[1])
+			}
+		}
+		// Indirect key references: only count matches that exist in en-us.yaml.
+		for _, m := range indirectKeyPattern.FindAllStringSubmatch(line, -1) {
+			if _, exists := keys[m[1]]; exists {
+				addRef(m[1])
+			}
+		}
+		// String literals inside tagged templates: only count matches
+		// that exist in en-us.yaml, like indirect references.
+		for _, t := range tagged {
+			for _, key := range t.scan(line) {
+				if _, exists := keys[key]; exists {
+					addRef(key)
 				}
 			}
-			// Dynamic template literal patterns.
-			dynamics = append(dynamics, extractDynamicPatterns(line, ref)...)
 		}
+		// Dynamic template literal patterns.
+		dynamics = append(dynamics, extractDynamicPatterns(line, ref, opts.greedyDynamic)...)
 	}
-	return refs, dynamics, nil
+	return dynamics, scanner.Err()
+}
+
+// stringConstants returns the string constants declared anywhere in a
+// file, by name. A name declared more than once (e.g. in different
+// functions) is ambiguous and left out.
+func stringConstants(src string) map[string]string {
+	consts, _ := readStringConstants(strings.NewReader(src))
+	return consts
+}
+
+// readStringConstants is stringConstants for a reader, scanned line by
+// line. A declaration split over several lines is matched by carrying
+// its unfinished start over to the next line.
+func readStringConstants(r io.Reader) (map[string]string, error) {
+	consts := make(map[string]string)
+	ambiguous := make(map[string]bool)
+	scanner := newLineScanner(r)
+	carry := ""
+	for scanner.Scan() {
+		text := carry + scanner.Text()
+		for _, m := range stringConstPattern.FindAllStringSubmatch(text, -1) {
+			if old, ok := consts[m[1]]; ok && old != m[2] {
+				ambiguous[m[1]] = true
+			}
+			consts[m[1]] = m[2]
+		}
+		carry = ""
+		if loc := unfinishedConstPattern.FindStringIndex(text); loc != nil {
+			carry = text[loc[0]:] + "\n"
+		}
+	}
+	for name := range ambiguous {
+		delete(consts, name)
+	}
+	return consts, scanner.Err()
+}
+
+// taggedTemplateState follows tag`...` templates across lines while a
+// file is scanned line by line.
+type taggedTemplateState struct {
+	opener *regexp.Regexp
+	open   bool // inside a template body
+}
+
+func newTaggedTemplateState(tag string) *taggedTemplateState {
+	return &taggedTemplateState{opener: regexp.MustCompile(`\b` + regexp.QuoteMeta(tag) + "\\s*\x60")}
+}
+
+// scan returns the quoted dotted strings on line that are inside a
+// tagged template, updating whether a template is still open at the end
+// of the line.
+func (s *taggedTemplateState) scan(line string) []string {
+	var keys []string
+	for line != "" {
+		if !s.open {
+			loc := s.opener.FindStringIndex(line)
+			if loc == nil {
+				break
+			}
+			line = line[loc[1]:]
+			s.open = true
+		}
+		body := line
+		line = ""
+		if end := strings.IndexByte(body, '\x60'); end >= 0 {
+			body, line = body[:end], body[end+1:]
+			s.open = false
+		}
+		for _, m := range taggedKeyLiteral.FindAllStringSubmatch(body, -1) {
+			keys = append(keys, m[1])
+		}
+	}
+	return keys
 }
 
 // findKeyReferences scans source files for translation key usage,
 // including dynamic template literal patterns.
-func findKeyReferences(root string, keys map[string]string) (map[string][]keyReference, error) {
-	refs, dynamics, err := scanFiles(root, keys)
+func findKeyReferences(root string, keys map[string]string, opts scanOptions) (map[string][]keyReference, error) {
+	refs, _, err := scanKeyReferences(root, keys, opts)
+	return refs, err
+}
+
+// scanKeyReferences is findKeyReferences that also returns the dynamic
+// patterns found along the way, for callers that need both.
+func scanKeyReferences(root string, keys map[string]string, opts scanOptions) (map[string][]keyReference, []dynamicKeyRef, error) {
+	refs, dynamics, err := scanFiles(root, keys, opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	done := timePhase("reference resolution")
+	resolveKeyReferences(refs, dynamics, keys, opts)
+	done()
+	if opts.excludeTests {
+		dropTestReferences(refs)
+	}
+	return refs, dynamics, nil
+}
+
+// isTestFile reports whether a source file is a spec or test file by its
+// base name (e.g. "foo.spec.ts", "bar.test.js").
+func isTestFile(path string) bool {
+	base := filepath.Base(path)
+	return strings.Contains(base, ".spec.") || strings.Contains(base, ".test.")
+}
+
+// dropTestReferences removes references located in test files, deleting
+// keys that are left without any reference.
+func dropTestReferences(refs map[string][]keyReference) {
+	for key, list := range refs {
+		var kept []keyReference
+		for _, r := range list {
+			if !isTestFile(r.File) {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == 0 {
+			delete(refs, key)
+		} else {
+			refs[key] = kept
+		}
+	}
+}
 
-	// Resolve dynamic patterns: mark all matching keys as referenced.
+// resolveKeyReferences adds the keys matched by dynamic patterns and
+// allowlisted prefixes to the literal references returned by scanFiles.
+func resolveKeyReferences(refs map[string][]keyReference, dynamics []dynamicKeyRef, keys map[string]string, opts scanOptions) {
+	// Resolve dynamic patterns: mark all matching keys as referenced,
+	// skipping lines that already reference the key.
+	index := newKeyIndex(keys)
 	for _, d := range dynamics {
-		for key := range keys {
-			if d.Regex.MatchString(key) {
+		for _, key := range index.candidates(d) {
+			if d.Regex.MatchString(key) && !containsRef(refs[key], d.Ref) {
 				refs[key] = append(refs[key], d.Ref)
 			}
 		}
 	}
 
-	return refs, nil
+	// Allowlisted prefixes count as references too.
+	for _, a := range opts.allowPrefixes {
+		for key := range keys {
+			if a.matches(key) {
+				refs[key] = append(refs[key], a.Ref)
+			}
+		}
+	}
+}
+
+// keyIndex groups keys by their first dotted segment, so that a dynamic
+// pattern only needs to be tested against the keys it could match.
+type keyIndex struct {
+	all     []string
+	byFirst map[string][]string
+}
+
+func newKeyIndex(keys map[string]string) keyIndex {
+	index := keyIndex{byFirst: make(map[string][]string)}
+	for key := range keys {
+		first, _, _ := strings.Cut(key, ".")
+		index.all = append(index.all, key)
+		index.byFirst[first] = append(index.byFirst[first], key)
+	}
+	return index
+}
+
+// candidates returns the keys a dynamic pattern may match. A pattern whose
+// first segment is literal (`nav.${page}`) can only match keys under that
+// segment; one that interpolates it (`${section}.title`) must test all.
+func (index keyIndex) candidates(d dynamicKeyRef) []string {
+	first, _, _ := strings.Cut(d.Template, ".")
+	if strings.Contains(first, "${") {
+		return index.all
+	}
+	return index.byFirst[first]
+}
+
+// containsRef reports whether refs already includes ref.
+func containsRef(refs []keyReference, ref keyReference) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
 }
 
 // findDynamicPatterns scans source files and returns only the dynamic
 // template literal patterns (without resolving them against keys).
-func findDynamicPatterns(root string) ([]dynamicKeyRef, error) {
-	_, dynamics, err := scanFiles(root, nil)
+func findDynamicPatterns(root string, opts scanOptions) ([]dynamicKeyRef, error) {
+	_, dynamics, err := scanFiles(root, nil, opts)
 	return dynamics, err
 }
+
+// callWarning records a translation call that is likely a bug.
+type callWarning struct {
+	Ref     keyReference `json:"ref"`
+	Message string       `json:"message"`
+}
+
+// lintTranslationCalls scans source files for t() calls whose key literal
+// has leading or trailing whitespace. Such keys are trimmed when counting
+// references, but never resolve at runtime. Paths matching an exclude
+// glob are skipped; see scanSourceFiles.
+func lintTranslationCalls(root string, exclude []string) ([]callWarning, error) {
+	files, err := keySourceFiles(root, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []callWarning
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		relPath, _ := filepath.Rel(root, file)
+		for i, line := range strings.Split(string(data), "\n") {
+			for _, m := range paddedKeyPattern.FindAllStringSubmatch(line, -1) {
+				warnings = append(warnings, callWarning{
+					Ref:     keyReference{File: relPath, Line: i + 1},
+					Message: fmt.Sprintf("t() key %q has leading/trailing whitespace", m[1]),
+				})
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// printCallWarnings writes call warnings to stderr.
+func printCallWarnings(warnings []callWarning) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s:%d: %s\n", w.Ref.File, w.Ref.Line, w.Message)
+	}
+}
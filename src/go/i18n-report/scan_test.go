@@ -1,7 +1,14 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +26,11 @@ func TestKeyPatterns(t *testing.T) {
 		{"$t", `$t('nav.home')`, "nav.home"},
 		{"preceded by space", ` t('key.name')`, "key.name"},
 		{"not preceded by letter", `xt('key.name')`, ""}, // "xt" has letter before t
+		{"padded key trimmed", `t(' nav.home ')`, "nav.home"},
+		{"$tc", `$tc('items.count', n)`, "items.count"},
+		{"this.tc", `this.tc("images.count", images.length)`, "images.count"},
+		{"tc", `tc('items.count', 2)`, "items.count"},
+		{"etc not a call", `etc('items.count')`, ""},
 
 		// keyPropPattern: titleKey/descriptionKey/labelKey with string values
 		{"titleKey", `titleKey: 'page.title'`, "page.title"},
@@ -134,6 +146,26 @@ func TestDynamicKeyLiteral(t *testing.T) {
 			"this.t(`snapshots.dialog.${ type }.actions.ok`)",
 			"snapshots.dialog.{}.actions.ok",
 		},
+		{
+			"method call in interpolation",
+			"this.t(`engine.${ this.getMode() }.label`)",
+			"engine.{}.label",
+		},
+		{
+			"conditional with indexed expression",
+			"this.t(`x.${ obj['a'] ? 'b' : 'c' }.y`)",
+			"x.{}.y",
+		},
+		{
+			"object literal indexed in interpolation",
+			"this.t(`status.${ { on: 'running', off: 'stopped' }[state] }.label`)",
+			"status.{}.label",
+		},
+		{
+			"quoted brace in interpolation",
+			"this.t(`menu.${ labels['}'] }.title`)",
+			"menu.{}.title",
+		},
 		{
 			"no interpolation (literal key)",
 			"t(`action.refresh`)",
@@ -149,7 +181,7 @@ func TestDynamicKeyLiteral(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ref := keyReference{File: "test.ts", Line: 1}
-			dynamics := extractDynamicPatterns(tc.line, ref)
+			dynamics := extractDynamicPatterns(tc.line, ref, false)
 
 			if tc.wantPattern == "" {
 				if len(dynamics) > 0 {
@@ -171,27 +203,35 @@ func TestTemplateToKeyRegex(t *testing.T) {
 	tests := []struct {
 		template string
 		key      string
+		greedy   bool
 		matches  bool
 	}{
-		{"containerEngine.options.${x}.label", "containerEngine.options.moby.label", true},
-		{"containerEngine.options.${x}.label", "containerEngine.options.containerd.label", true},
-		{"containerEngine.options.${x}.label", "containerEngine.options.label", false},     // no segment
-		{"containerEngine.options.${x}.label", "containerEngine.label", false},              // different structure
-		{"asyncButton.${mode}.${phase}", "asyncButton.edit.action", true},
-		{"asyncButton.${mode}.${phase}", "asyncButton.default.success", true},
-		{"asyncButton.${mode}.${phase}", "asyncButton.edit", false},                        // too few segments
-		{"asyncButton.${mode}.${phase}Icon", "asyncButton.edit.actionIcon", true},
-		{"asyncButton.${mode}.${phase}Icon", "asyncButton.edit.action", false},             // missing Icon suffix
-		{"virtualMachine.type.options.${x}.label", "virtualMachine.type.options.qemu.label", true},
-		{"virtualMachine.type.options.${x}.label", "virtualMachine.type.options.vz.label", true},
-		{"snapshots.dialog.${type}.actions.ok", "snapshots.dialog.delete.actions.ok", true},
-		{"snapshots.dialog.${type}.actions.ok", "snapshots.dialog.restore.actions.ok", true},
-		{"snapshots.dialog.${type}.actions.ok", "snapshots.info.create.success", false},    // different prefix
+		{"containerEngine.options.${x}.label", "containerEngine.options.moby.label", false, true},
+		{"containerEngine.options.${x}.label", "containerEngine.options.containerd.label", false, true},
+		{"containerEngine.options.${x}.label", "containerEngine.options.label", false, false}, // no segment
+		{"containerEngine.options.${x}.label", "containerEngine.label", false, false},         // different structure
+		{"asyncButton.${mode}.${phase}", "asyncButton.edit.action", false, true},
+		{"asyncButton.${mode}.${phase}", "asyncButton.default.success", false, true},
+		{"asyncButton.${mode}.${phase}", "asyncButton.edit", false, false}, // too few segments
+		{"asyncButton.${mode}.${phase}Icon", "asyncButton.edit.actionIcon", false, true},
+		{"asyncButton.${mode}.${phase}Icon", "asyncButton.edit.action", false, false}, // missing Icon suffix
+		{"virtualMachine.type.options.${x}.label", "virtualMachine.type.options.qemu.label", false, true},
+		{"virtualMachine.type.options.${x}.label", "virtualMachine.type.options.vz.label", false, true},
+		{"snapshots.dialog.${type}.actions.ok", "snapshots.dialog.delete.actions.ok", false, true},
+		{"snapshots.dialog.${type}.actions.ok", "snapshots.dialog.restore.actions.ok", false, true},
+		{"snapshots.dialog.${type}.actions.ok", "snapshots.info.create.success", false, false}, // different prefix
+		{"errors.${code}", "errors.network.timeout", false, false},                             // strict: one segment only
+		{"errors.${code}", "errors.network.timeout", true, true},                               // greedy spans segments
+		{"errors.${code}", "errors.simple", true, true},
+		{"errors.${code}.label", "errors.network.timeout.label", true, true},
+		{"errors.${code}.label", "errors.label", true, false}, // still needs a segment
+		{"errors.${code}", "errors.network.", true, false},    // no empty segments
+		{"status.${ { on: 'up' }[s] }.label", "status.on.label", false, true},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.template+"→"+tc.key, func(t *testing.T) {
-			re := templateToKeyRegex(tc.template)
+			re := templateToKeyRegex(tc.template, tc.greedy)
 			if re == nil {
 				t.Fatal("templateToKeyRegex returned nil")
 			}
@@ -202,3 +242,417 @@ func TestTemplateToKeyRegex(t *testing.T) {
 		})
 	}
 }
+
+func TestPaddedKeyPattern(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{`t(' nav.home ')`, true},
+		{`t(' nav.home')`, true},
+		{`t("nav.home ")`, true},
+		{`t('nav.home')`, false},
+		{`this.t('nav.home', { name })`, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.line, func(t *testing.T) {
+			if got := paddedKeyPattern.MatchString(tc.line); got != tc.want {
+				t.Errorf("paddedKeyPattern.MatchString(%q) = %v, want %v", tc.line, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadPrefixAllowlist(t *testing.T) {
+	path := t.TempDir() + "/allow.txt"
+	content := `# Keys built from API responses
+errors.api
+  preferences.dynamic.   # trailing comment
+
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prefixes, err := loadPrefixAllowlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prefixes) != 2 {
+		t.Fatalf("got %d prefixes, want 2: %+v", len(prefixes), prefixes)
+	}
+	if prefixes[0].Prefix != "errors.api" || prefixes[0].Ref.Line != 2 {
+		t.Errorf("prefixes[0] = %+v", prefixes[0])
+	}
+	if prefixes[1].Prefix != "preferences.dynamic." || prefixes[1].Ref.Line != 3 {
+		t.Errorf("prefixes[1] = %+v", prefixes[1])
+	}
+
+	tests := []struct {
+		prefix string
+		key    string
+		want   bool
+	}{
+		{"errors.api", "errors.api", true},
+		{"errors.api", "errors.api.timeout", true},
+		{"errors.api", "errors.apiVersion", false},
+		{"preferences.dynamic.", "preferences.dynamic.foo", true},
+		{"preferences.dynamic.", "preferences.dynamicFoo", false},
+	}
+	for _, tc := range tests {
+		got := allowedPrefix{Prefix: tc.prefix}.matches(tc.key)
+		if got != tc.want {
+			t.Errorf("%q matches %q = %v, want %v", tc.prefix, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestScanFilesDeduplicatesPerLine(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "pages")
+	os.MkdirAll(srcDir, 0755)
+	src := "const tab = { titleKey: 'nav.home' };\n" +
+		"const other = t('nav.home') + t(`nav.${ page }`);\n"
+	os.WriteFile(filepath.Join(srcDir, "Home.vue"), []byte(src), 0644)
+
+	keys := map[string]string{"nav.home": "Home"}
+	refs, err := findKeyReferences(root, keys, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Line 1 matches keyPropPattern, dottedKeyLiteral and indirectKeyPattern;
+	// line 2 matches keyPattern and a dynamic pattern. Each counts once.
+	want := []keyReference{
+		{File: filepath.Join("pkg", "rancher-desktop", "pages", "Home.vue"), Line: 1},
+		{File: filepath.Join("pkg", "rancher-desktop", "pages", "Home.vue"), Line: 2},
+	}
+	if !reflect.DeepEqual(refs["nav.home"], want) {
+		t.Errorf("got %v, want %v", refs["nav.home"], want)
+	}
+}
+
+func TestTaggedTemplateKeys(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "pages")
+	os.MkdirAll(srcDir, 0755)
+	src := "const q = i18nQuery`\n" +
+		"  title(ref \"nav.home\")\n" +
+		"  label(ref 'nav.unknown')\n" +
+		"`;\n" +
+		"const other = css`content 'nav.about';`;\n"
+	os.WriteFile(filepath.Join(srcDir, "Query.ts"), []byte(src), 0644)
+
+	keys := map[string]string{"nav.home": "Home", "nav.about": "About"}
+
+	refs, err := findKeyReferences(root, keys, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := refs["nav.home"]; found {
+		t.Error("nav.home referenced without --scan-tag")
+	}
+
+	refs, err = findKeyReferences(root, keys, scanOptions{scanTags: []string{"i18nQuery"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []keyReference{{File: filepath.Join("pkg", "rancher-desktop", "pages", "Query.ts"), Line: 2}}
+	if !reflect.DeepEqual(refs["nav.home"], want) {
+		t.Errorf("nav.home: got %v, want %v", refs["nav.home"], want)
+	}
+	if _, found := refs["nav.about"]; found {
+		t.Error("nav.about in an unrelated tag should not be referenced")
+	}
+}
+
+func TestExcludeTests(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "components")
+	os.MkdirAll(srcDir, 0755)
+	os.WriteFile(filepath.Join(srcDir, "Nav.vue"), []byte("t('nav.home')\n"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "Nav.spec.ts"), []byte("t('nav.home'); t('nav.testOnly')\n"), 0644)
+
+	keys := map[string]string{"nav.home": "Home", "nav.testOnly": "Test"}
+
+	refs, err := findKeyReferences(root, keys, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs["nav.home"]) != 2 || len(refs["nav.testOnly"]) != 1 {
+		t.Errorf("without --exclude-tests: got %v", refs)
+	}
+
+	refs, err = findKeyReferences(root, keys, scanOptions{excludeTests: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []keyReference{{File: filepath.Join("pkg", "rancher-desktop", "components", "Nav.vue"), Line: 1}}
+	if !reflect.DeepEqual(refs["nav.home"], want) {
+		t.Errorf("nav.home: got %v, want %v", refs["nav.home"], want)
+	}
+	if _, found := refs["nav.testOnly"]; found {
+		t.Error("nav.testOnly should be unreferenced with --exclude-tests")
+	}
+}
+
+func TestConstantConcatenation(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "components")
+	os.MkdirAll(srcDir, 0755)
+	src := "const baseKey = 'containerEngine.options.moby';\n" +
+		"export default {\n" +
+		"  computed: {\n" +
+		"    label() { return this.t(baseKey + '.label'); },\n" +
+		"    other() { return t(unknown + '.label'); },\n" +
+		"  },\n" +
+		"};\n"
+	os.WriteFile(filepath.Join(srcDir, "Engine.vue"), []byte(src), 0644)
+
+	keys := map[string]string{"containerEngine.options.moby.label": "dockerd (moby)"}
+	refs, err := findKeyReferences(root, keys, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []keyReference{{File: filepath.Join("pkg", "rancher-desktop", "components", "Engine.vue"), Line: 4}}
+	if !reflect.DeepEqual(refs["containerEngine.options.moby.label"], want) {
+		t.Errorf("got %v, want %v", refs["containerEngine.options.moby.label"], want)
+	}
+}
+
+func TestNamespacedKeys(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "components")
+	os.MkdirAll(srcDir, 0755)
+	src := "i18next.t('common:foo.bar');\n" +
+		"t(\"common:save\");\n" +
+		"t('nav.home');\n"
+	os.WriteFile(filepath.Join(srcDir, "Save.ts"), []byte(src), 0644)
+
+	keys := map[string]string{"common.foo.bar": "Bar", "common.save": "Save", "nav.home": "Home"}
+
+	refs, err := findKeyReferences(root, keys, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := refs["common.foo.bar"]; found {
+		t.Error("common:foo.bar referenced without --namespaces")
+	}
+
+	refs, err = findKeyReferences(root, keys, scanOptions{namespaces: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join("pkg", "rancher-desktop", "components", "Save.ts")
+	for key, line := range map[string]int{"common.foo.bar": 1, "common.save": 2, "nav.home": 3} {
+		want := []keyReference{{File: file, Line: line}}
+		if !reflect.DeepEqual(refs[key], want) {
+			t.Errorf("%s: got %v, want %v", key, refs[key], want)
+		}
+	}
+}
+
+func TestScanTSXFiles(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "components")
+	os.MkdirAll(srcDir, 0755)
+	src := "export const Home = () => (\n" +
+		"  <h1>{t('nav.home')}</h1>\n" +
+		");\n"
+	os.WriteFile(filepath.Join(srcDir, "Home.tsx"), []byte(src), 0644)
+	os.WriteFile(filepath.Join(srcDir, "About.jsx"), []byte("<p>{t('nav.about')}</p>\n"), 0644)
+
+	keys := map[string]string{"nav.home": "Home", "nav.about": "About"}
+	refs, err := findKeyReferences(root, keys, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []keyReference{{File: filepath.Join("pkg", "rancher-desktop", "components", "Home.tsx"), Line: 2}}
+	if !reflect.DeepEqual(refs["nav.home"], want) {
+		t.Errorf("nav.home: got %v, want %v", refs["nav.home"], want)
+	}
+	if len(refs["nav.about"]) != 1 {
+		t.Errorf("nav.about: got %v, want one reference from About.jsx", refs["nav.about"])
+	}
+}
+
+func TestStringConstants(t *testing.T) {
+	src := "const a = 'x.y';\nconst b = \"z\";\nfunction f() { const a = 'other'; }\nconst n = 5;\n" +
+		"const split =\n  'containerEngine.options';\nconst\n  spread\n\n  = 'prefs.tab';\n"
+	got := stringConstants(src)
+	want := map[string]string{"b": "z", "split": "containerEngine.options", "spread": "prefs.tab"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestScanFilesLongLine(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "pkg", "rancher-desktop", "pages")
+	os.MkdirAll(srcDir, 0755)
+	// A generated bundle line longer than bufio.Scanner's default buffer.
+	long := "const data = '" + strings.Repeat("x", 200*1024) + "'; t('nav.about');\n"
+	os.WriteFile(filepath.Join(srcDir, "generated.ts"), []byte(long+"t('nav.home');\n"), 0644)
+
+	keys := map[string]string{"nav.home": "Home", "nav.about": "About"}
+	refs, _, err := scanFiles(root, keys, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join("pkg", "rancher-desktop", "pages", "generated.ts")
+	want := map[string][]keyReference{
+		"nav.about": {{File: file, Line: 1}},
+		"nav.home":  {{File: file, Line: 2}},
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("got %v, want %v", refs, want)
+	}
+}
+
+func TestKeyIndexCandidates(t *testing.T) {
+	keys := map[string]string{"nav.home": "", "nav.about": "", "tray.title": "", "navigation.title": ""}
+	index := newKeyIndex(keys)
+
+	got := index.candidates(dynamicKeyRef{Template: "nav.${page}"})
+	sort.Strings(got)
+	if want := []string{"nav.about", "nav.home"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("literal prefix: got %v, want %v", got, want)
+	}
+	if got := index.candidates(dynamicKeyRef{Template: "${section}.title"}); len(got) != len(keys) {
+		t.Errorf("interpolated prefix: got %v, want all keys", got)
+	}
+	if got := index.candidates(dynamicKeyRef{Template: "nav${suffix}.title"}); len(got) != len(keys) {
+		t.Errorf("partly interpolated prefix: got %v, want all keys", got)
+	}
+}
+
+// benchmarkDynamicFixture returns a synthetic set of 5000 keys in 50
+// groups and 200 dynamic patterns, one in ten with an interpolated first
+// segment.
+func benchmarkDynamicFixture() (map[string]string, []dynamicKeyRef) {
+	keys := make(map[string]string, 5000)
+	for g := 0; g < 50; g++ {
+		for k := 0; k < 100; k++ {
+			keys[fmt.Sprintf("group%d.item%d.label", g, k)] = "Label"
+		}
+	}
+	var dynamics []dynamicKeyRef
+	for i := 0; i < 200; i++ {
+		template := fmt.Sprintf("group%d.${item}.label", i%50)
+		if i%10 == 0 {
+			template = "${group}.item" + strconv.Itoa(i%100) + ".label"
+		}
+		dynamics = append(dynamics, dynamicKeyRef{
+			Template: template,
+			Regex:    templateToKeyRegex(template, false),
+			Ref:      keyReference{File: "bench.ts", Line: i + 1},
+		})
+	}
+	return keys, dynamics
+}
+
+// BenchmarkResolveDynamicLinear measures the previous approach, testing
+// every pattern against every key.
+func BenchmarkResolveDynamicLinear(b *testing.B) {
+	keys, dynamics := benchmarkDynamicFixture()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		refs := make(map[string][]keyReference)
+		for _, d := range dynamics {
+			for key := range keys {
+				if d.Regex.MatchString(key) && !containsRef(refs[key], d.Ref) {
+					refs[key] = append(refs[key], d.Ref)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkResolveDynamicIndexed(b *testing.B) {
+	keys, dynamics := benchmarkDynamicFixture()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resolveKeyReferences(make(map[string][]keyReference), dynamics, keys, scanOptions{})
+	}
+}
+
+func TestIsExcluded(t *testing.T) {
+	exclude := []string{"generated", "**/*.generated.ts", "pages/*.vue"}
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{"generated", true},
+		{"components/generated", false},
+		{"api.generated.ts", true},
+		{"store/api.generated.ts", true},
+		{"store/api.ts", false},
+		{"pages/Home.vue", true},
+		{"pages/images/Add.vue", false},
+	}
+	for _, tt := range tests {
+		if got := isExcluded(tt.rel, exclude); got != tt.want {
+			t.Errorf("isExcluded(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestScanSourceFilesExclude(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"pages/Home.vue", "generated/strings.ts", "node_modules/dep/index.ts", "store/api.generated.ts"} {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, nil, 0644)
+	}
+
+	files, err := scanSourceFiles(root, root, keySourceExts, []string{"generated", "**/*.generated.ts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "pages", "Home.vue")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("got %v, want %v", files, want)
+	}
+}
+
+func TestKeySourceFilesExcludeFromRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"pkg/rancher-desktop/pages/Home.vue", "pkg/rancher-desktop/generated/strings.ts", "background.ts", "scratch.ts"} {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, nil, 0644)
+	}
+
+	files, err := keySourceFiles(root, []string{"pkg/rancher-desktop/generated", "scratch.ts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(root, "pkg", "rancher-desktop", "pages", "Home.vue"),
+		filepath.Join(root, "background.ts"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("got %v, want %v", files, want)
+	}
+}
+
+func TestSplitInterpolations(t *testing.T) {
+	tests := []struct {
+		template string
+		want     []string
+	}{
+		{"nav.home", []string{"nav.home"}},
+		{"nav.${page}", []string{"nav.", ""}},
+		{"a.${x}.${y}Icon", []string{"a.", ".", "Icon"}},
+		{"x.${ obj['a'] ? 'b' : 'c' }.y", []string{"x.", ".y"}},
+		{"x.${ {a: 1}[k] }.y", []string{"x.", ".y"}},
+		{"x.${ m[\"}\"] }.y", []string{"x.", ".y"}},
+		{"x.${ fn(`${inner}`) }.y", []string{"x.", ".y"}},
+		{"x.${ open", []string{"x.${ open"}},
+	}
+	for _, tc := range tests {
+		if got := splitInterpolations(tc.template); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitInterpolations(%q) = %q, want %q", tc.template, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// changedSourceFiles returns the source files (relative to root) that
+// differ between ref and the working tree, including deleted files.
+func changedSourceFiles(root, ref string) ([]string, error) {
+	cmd := exec.Command("git", "diff", "--name-only", "--relative", ref, "--")
+	cmd.Dir = root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git diff %s: %s", ref, msg)
+		}
+		return nil, fmt.Errorf("git diff %s: %w", ref, err)
+	}
+	var files []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" && slices.Contains(keySourceExts, filepath.Ext(line)) {
+			files = append(files, filepath.FromSlash(line))
+		}
+	}
+	return files, nil
+}
+
+// sinceFileSet returns the absolute paths of the source files changed
+// since ref, for scanOptions.onlyFiles. If git cannot produce the list, it
+// warns on stderr and returns nil so the caller falls back to a full scan.
+func sinceFileSet(root, ref string) map[string]bool {
+	files, err := changedSourceFiles(root, ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; scanning all files\n", err)
+		return nil
+	}
+	set := make(map[string]bool, len(files))
+	for _, f := range files {
+		set[filepath.Join(root, f)] = true
+	}
+	return set
+}
+
+// gitShowFile returns the contents of relPath at ref, or false if the file
+// did not exist there.
+func gitShowFile(root, ref, relPath string) (string, bool) {
+	cmd := exec.Command("git", "show", ref+":./"+filepath.ToSlash(relPath))
+	cmd.Dir = root
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	return string(out), true
+}
+
+// removedKeyReferences compares the changed files at ref with the working
+// tree and returns the keys that were referenced in the old contents but
+// no longer are in the new. A full scan is not run, so a returned key may
+// still be used by an unchanged file.
+func removedKeyReferences(root, ref string, files []string, keys map[string]string, opts scanOptions) []string {
+	oldRefs := make(map[string][]keyReference)
+	newRefs := make(map[string][]keyReference)
+	for _, f := range files {
+		if src, ok := gitShowFile(root, ref, f); ok {
+			scanSource(f, src, keys, opts, oldRefs)
+		}
+		scanFile(filepath.Join(root, f), f, keys, opts, newRefs)
+	}
+
+	var removed []string
+	for _, k := range sortedKeys(keys) {
+		if len(oldRefs[k]) > 0 && len(newRefs[k]) == 0 {
+			removed = append(removed, k)
+		}
+	}
+	return removed
+}
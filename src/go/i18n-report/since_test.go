@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// gitRepoFixture creates a git repository with one committed Vue file and
+// returns its root.
+func gitRepoFixture(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	pages := filepath.Join(root, "pkg", "rancher-desktop", "pages")
+	os.MkdirAll(pages, 0755)
+	os.WriteFile(filepath.Join(pages, "Home.vue"), []byte("{{ t('nav.home') }}\n{{ t('nav.about') }}\n"), 0644)
+	os.WriteFile(filepath.Join(pages, "Gone.vue"), []byte("{{ t('nav.gone') }}\n"), 0644)
+	os.WriteFile(filepath.Join(root, "notes.txt"), []byte("t('nav.home')\n"), 0644)
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	return root
+}
+
+func TestChangedSourceFiles(t *testing.T) {
+	root := gitRepoFixture(t)
+	home := filepath.Join(root, "pkg", "rancher-desktop", "pages", "Home.vue")
+	os.WriteFile(home, []byte("{{ t('nav.home') }}\n"), 0644)
+	os.Remove(filepath.Join(root, "pkg", "rancher-desktop", "pages", "Gone.vue"))
+	os.WriteFile(filepath.Join(root, "notes.txt"), []byte("changed\n"), 0644)
+
+	files, err := changedSourceFiles(root, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join("pkg", "rancher-desktop", "pages", "Gone.vue"),
+		filepath.Join("pkg", "rancher-desktop", "pages", "Home.vue"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("changedSourceFiles = %v, want %v", files, want)
+	}
+
+	keys := map[string]string{"nav.home": "Home", "nav.about": "About", "nav.gone": "Gone"}
+	removed := removedKeyReferences(root, "HEAD", files, keys, scanOptions{})
+	if want := []string{"nav.about", "nav.gone"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removedKeyReferences = %v, want %v", removed, want)
+	}
+}
+
+func TestChangedSourceFilesBadRef(t *testing.T) {
+	root := gitRepoFixture(t)
+	if _, err := changedSourceFiles(root, "no-such-ref"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+	if got := sinceFileSet(root, "no-such-ref"); got != nil {
+		t.Errorf("sinceFileSet = %v, want nil to fall back to a full scan", got)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// keyWordPattern matches the words of a value used in a suggested key.
+	keyWordPattern = regexp.MustCompile(`[A-Za-z0-9]+`)
+	// dialogFieldPattern captures the property name of a dialog string.
+	dialogFieldPattern = regexp.MustCompile(`(title|message|detail|description):\s+['"]`)
+	// buttonPattern spots a hit inside a button element.
+	buttonPattern = regexp.MustCompile(`<(?:button|rd-button|async-button)\b`)
+)
+
+// maxKeyWords caps the words of a value that go into a suggested key.
+const maxKeyWords = 4
+
+// genericPathSegments are source path segments that say nothing about
+// the feature a string belongs to, and are left out of suggested keys.
+var genericPathSegments = map[string]bool{
+	"components": true,
+	"pages":      true,
+	"index":      true,
+}
+
+// suggestKeys sets the Suggestion of each hit: a dotted key built from
+// its file path and matched value, such as "preferences.general.resetButton"
+// for a "Reset" button in components/Preferences/General.vue. Suggestions
+// never collide with the existing keys or with each other, except that
+// the same value in the same file shares one key.
+func suggestKeys(hits []untranslatedHit, keys map[string]string) {
+	taken := make(map[string]bool)
+	bySource := make(map[string]string)
+	for i, h := range hits {
+		base := suggestedKey(h)
+		source := base + "\x00" + h.Value
+		if key, ok := bySource[source]; ok {
+			hits[i].Suggestion = key
+			continue
+		}
+		key := base
+		for n := 2; taken[key] || keyCollides(key, keys); n++ {
+			key = fmt.Sprintf("%s%d", base, n)
+		}
+		taken[key] = true
+		bySource[source] = key
+		hits[i].Suggestion = key
+	}
+}
+
+// suggestedKey returns the key for a hit before collisions are resolved.
+func suggestedKey(h untranslatedHit) string {
+	segments := filePathSegments(h.File)
+	return strings.Join(append(segments, valueSegment(h.Value, hitKind(h.Context))), ".")
+}
+
+// filePathSegments turns a source path into key segments: the directories
+// under pkg/rancher-desktop and the file name, in lower camel case, without
+// generic segments such as "components".
+func filePathSegments(relPath string) []string {
+	rel := filepath.ToSlash(relPath)
+	rel = strings.TrimPrefix(rel, "pkg/rancher-desktop/")
+	rel = strings.TrimSuffix(rel, filepath.Ext(rel))
+	var segments []string
+	for _, part := range strings.Split(rel, "/") {
+		s := lowerCamel(keyWordPattern.FindAllString(part, -1))
+		if s != "" && !genericPathSegments[s] {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+// hitKind names the UI slot a hit sits in ("button", "placeholder",
+// "title", "error", ...), or returns "" when the context does not say.
+func hitKind(context string) string {
+	if m := attrPattern.FindStringSubmatch(context); m != nil {
+		return strings.ToLower(m[1])
+	}
+	if m := boundLiteralPattern.FindStringSubmatch(context); m != nil {
+		return m[1]
+	}
+	if m := dialogFieldPattern.FindStringSubmatch(context); m != nil {
+		return m[1]
+	}
+	if buttonPattern.MatchString(context) {
+		return "button"
+	}
+	if newErrorPattern.MatchString(context) {
+		return "error"
+	}
+	return ""
+}
+
+// valueSegment builds the last key segment from the first words of a
+// value followed by its kind, as in "resetButton".
+func valueSegment(value, kind string) string {
+	words := keyWordPattern.FindAllString(value, -1)
+	if len(words) > maxKeyWords {
+		words = words[:maxKeyWords]
+	}
+	if len(words) == 0 {
+		words = []string{"text"}
+	}
+	kindWords := keyWordPattern.FindAllString(kind, -1)
+	if len(kindWords) > 0 && !strings.EqualFold(words[len(words)-1], strings.Join(kindWords, "")) {
+		words = append(words, kindWords...)
+	}
+	return lowerCamel(words)
+}
+
+// lowerCamel joins words in lower camel case. A word that is all capitals
+// (an acronym such as "WSL") is lowercased whole when it comes first;
+// otherwise only its first letter changes case.
+func lowerCamel(words []string) string {
+	var sb strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			if strings.ToUpper(w) == w {
+				sb.WriteString(strings.ToLower(w))
+			} else {
+				sb.WriteString(lowerLeadingCaps(w))
+			}
+			continue
+		}
+		sb.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return sb.String()
+}
+
+// lowerLeadingCaps lowercases the leading run of capitals in a word,
+// keeping the last one when it starts the next word ("WSLIntegrations"
+// becomes "wslIntegrations").
+func lowerLeadingCaps(w string) string {
+	n := 0
+	for n < len(w) && w[n] >= 'A' && w[n] <= 'Z' {
+		n++
+	}
+	if n > 1 && n < len(w) {
+		n--
+	}
+	return strings.ToLower(w[:n]) + w[n:]
+}
+
+// keyCollides reports whether key cannot be added to keys: it exists,
+// it is already a namespace of other keys, or one of its parents is a
+// leaf value.
+func keyCollides(key string, keys map[string]string) bool {
+	if _, found := keys[key]; found {
+		return true
+	}
+	for k := range keys {
+		if strings.HasPrefix(k, key+".") || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+	return false
+}
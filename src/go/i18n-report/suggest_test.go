@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestKeys(t *testing.T) {
+	hits := []untranslatedHit{
+		{File: "pkg/rancher-desktop/components/Preferences/General.vue", Context: `<button class="btn" @click="reset">Reset</button>`, Value: "Reset"},
+		{File: "pkg/rancher-desktop/components/Preferences/General.vue", Context: `<input placeholder="Enter a name">`, Value: "Enter a name"},
+		{File: "pkg/rancher-desktop/components/Preferences/General.vue", Context: `<input placeholder="Enter a name" disabled>`, Value: "Enter a name"},
+		{File: "pkg/rancher-desktop/components/WSLIntegrations.vue", Context: `<h1>Integrations</h1>`, Value: "Integrations"},
+		{File: "pkg/rancher-desktop/pages/images/index.vue", Context: `title: 'Delete Image',`, Value: "Delete Image"},
+	}
+	keys := map[string]string{
+		"preferences.general.enterANamePlaceholder": "Name",
+		"images.deleteImageTitle.confirm":           "Sure?",
+	}
+	suggestKeys(hits, keys)
+
+	var got []string
+	for _, h := range hits {
+		got = append(got, h.Suggestion)
+	}
+	want := []string{
+		"preferences.general.resetButton",
+		"preferences.general.enterANamePlaceholder2",
+		"preferences.general.enterANamePlaceholder2",
+		"wslIntegrations.integrations",
+		"images.deleteImageTitle2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// verbose enables diagnostic progress and timing output on stderr. It is
+// set by the global -v/--verbose flag given before the subcommand.
+var verbose bool
+
+// scanProgressInterval is how many files are scanned between progress
+// lines in verbose mode.
+const scanProgressInterval = 500
+
+// logf prints a diagnostic line to stderr in verbose mode.
+func logf(format string, args ...any) {
+	if verbose {
+		fmt.Fprintf(os.Stderr, "[verbose] "+format+"\n", args...)
+	}
+}
+
+// timePhase starts timing a phase and returns a function that logs its
+// duration in verbose mode, for use as: defer timePhase("scan")().
+func timePhase(name string) func() {
+	if !verbose {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		logf("%s: %s", name, time.Since(start).Round(time.Millisecond))
+	}
+}
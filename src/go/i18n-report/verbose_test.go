@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerboseLogging(t *testing.T) {
+	dir := writeCheckFixture(t)
+
+	out, err := captureStderr(t, func() error {
+		_, err := runChecks(dir, "de", checkOptions{})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "" {
+		t.Errorf("expected no diagnostics without verbose, got %q", out)
+	}
+
+	verbose = true
+	defer func() { verbose = false }()
+	out, err = captureStderr(t, func() error {
+		_, err := runChecks(dir, "de", checkOptions{})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"load en-us.yaml:", "found 1 source files", "source scan:", "reference resolution:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in verbose output, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// watchInterval is how often --watch polls the tree for changes. A change
+// is acted on once the tree has been stable for one further interval, so
+// a bulk save triggers a single re-run.
+const watchInterval = 500 * time.Millisecond
+
+// treeStamp fingerprints the translation files and scanned source files
+// by path, size, and modification time. Any edit, addition, or removal
+// changes the result. Source files matching an exclude glob are left out,
+// as they are from the scan.
+func treeStamp(root string, exclude []string) uint64 {
+	h := fnv.New64a()
+	files, _ := keySourceFiles(root, exclude)
+	translations, _ := findTranslationFiles(root)
+	for _, f := range append(files, translations...) {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", f, info.Size(), info.ModTime().UnixNano())
+	}
+	return h.Sum64()
+}
+
+// watchLoop calls run once, then again whenever stamp changes and has
+// settled, until stop is closed.
+func watchLoop(stamp func() uint64, run func(), interval time.Duration, stop <-chan struct{}) {
+	last := stamp()
+	run()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		current := stamp()
+		if current == last {
+			continue
+		}
+		// Wait for the tree to stop changing before re-running.
+		for settled := false; !settled; {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			next := stamp()
+			settled = next == current
+			current = next
+		}
+		last = current
+		run()
+	}
+}
+
+// watchCheck re-runs the check whenever the translations or source files
+// change, until interrupted. Each run starts on a cleared screen when
+// stdout is a terminal, or after a timestamped separator otherwise.
+func watchCheck(root, locale, format string, opts checkOptions) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	stop := make(chan struct{})
+	go func() {
+		<-interrupt
+		close(stop)
+	}()
+
+	terminal := isTerminal(os.Stdout)
+	run := func() {
+		if terminal {
+			fmt.Print("\033[H\033[2J")
+		} else {
+			fmt.Printf("--- %s ---\n", time.Now().Format(time.TimeOnly))
+		}
+		if err := reportCheck(root, locale, format, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		fmt.Fprintln(os.Stderr, "Watching for changes (Ctrl-C to stop)...")
+	}
+	watchLoop(func() uint64 { return treeStamp(root, opts.scan.exclude) }, run, watchInterval, stop)
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTreeStamp(t *testing.T) {
+	root := writeCheckFixture(t)
+	before := treeStamp(root, nil)
+	if again := treeStamp(root, nil); again != before {
+		t.Fatal("stamp changed without any edit")
+	}
+
+	home := filepath.Join(root, "pkg", "rancher-desktop", "pages", "Home.vue")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(home, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if treeStamp(root, nil) == before {
+		t.Error("stamp unchanged after touching a source file")
+	}
+}
+
+func TestWatchLoopDebounces(t *testing.T) {
+	// The tree changes at poll 2 and keeps changing through poll 4; one
+	// re-run should follow once it settles.
+	stamps := []uint64{1, 1, 2, 3, 4, 4, 4, 4, 4, 4}
+	polls := 0
+	stop := make(chan struct{})
+	stamp := func() uint64 {
+		s := stamps[polls]
+		if polls < len(stamps)-1 {
+			polls++
+		} else {
+			select {
+			case <-stop:
+			default:
+				close(stop)
+			}
+		}
+		return s
+	}
+	runs := 0
+	watchLoop(stamp, func() { runs++ }, time.Millisecond, stop)
+	if runs != 2 {
+		t.Errorf("run called %d times, want 2 (initial and one after the change settles)", runs)
+	}
+}
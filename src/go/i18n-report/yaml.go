@@ -3,13 +3,17 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 )
 
-// flattenYAML flattens a nested YAML map into dotted keys.
+// flattenYAML flattens a nested YAML map into dotted keys. Sequence
+// elements become indexed keys (e.g. "key.0", "key.1").
 func flattenYAML(prefix string, node map[string]interface{}) map[string]string {
 	result := make(map[string]string)
 	for k, v := range node {
@@ -17,20 +21,38 @@ func flattenYAML(prefix string, node map[string]interface{}) map[string]string {
 		if prefix != "" {
 			key = prefix + "." + k
 		}
-		switch val := v.(type) {
-		case map[string]interface{}:
-			for fk, fv := range flattenYAML(key, val) {
-				result[fk] = fv
-			}
-		default:
-			result[key] = fmt.Sprintf("%v", val)
-		}
+		flattenYAMLValue(key, v, result)
 	}
 	return result
 }
 
+// flattenYAMLValue adds the leaves of a decoded YAML value under key.
+func flattenYAMLValue(key string, v interface{}, result map[string]string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for fk, fv := range flattenYAML(key, val) {
+			result[fk] = fv
+		}
+	case []interface{}:
+		for i, item := range val {
+			flattenYAMLValue(key+"."+strconv.Itoa(i), item, result)
+		}
+	default:
+		result[key] = fmt.Sprintf("%v", val)
+	}
+}
+
 // loadYAMLFlat loads a YAML file and returns flattened key-value pairs.
+// A .json file is decoded as nested JSON and flattened the same way.
 func loadYAMLFlat(path string) (map[string]string, error) {
+	defer timePhase("load " + filepath.Base(path))()
+	if isJSONFile(path) {
+		raw, err := loadJSONFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return flattenJSON(raw), nil
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -44,7 +66,18 @@ func loadYAMLFlat(path string) (map[string]string, error) {
 
 // loadYAMLWithComments loads a YAML file and returns flattened entries
 // that preserve YAML comments (e.g. @reason, @context annotations).
+// A .json file is decoded as nested JSON; its entries have no comments.
 func loadYAMLWithComments(path string) (map[string]mergeEntry, error) {
+	defer timePhase("load " + filepath.Base(path))()
+	if isJSONFile(path) {
+		raw, err := loadJSONFile(path)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]mergeEntry)
+		flattenJSONEntries("", raw, result)
+		return result, nil
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -60,29 +93,109 @@ func loadYAMLWithComments(path string) (map[string]mergeEntry, error) {
 	return result, nil
 }
 
-// flattenNodeWithComments recursively flattens a yaml.Node tree into
-// dotted keys, preserving HeadComment from leaf key nodes.
-func flattenNodeWithComments(prefix string, node *yaml.Node, result map[string]mergeEntry) {
-	if node.Kind != yaml.MappingNode {
-		return
+// yamlPair is a key node and its value node in a YAML mapping.
+type yamlPair struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// resolveAlias follows an alias node (*name) to its anchored node.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
 	}
+	return node
+}
+
+// isMergeKey reports whether a mapping key is the YAML merge key "<<".
+func isMergeKey(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge"
+}
+
+// mappingPairs returns the pairs of a mapping node in document order with
+// aliased values resolved, so shared subtrees flatten under every parent
+// that uses them. A "<<: *anchor" merge key is replaced by the anchored
+// mapping's pairs; as in YAML, keys set explicitly in the mapping win,
+// and with several merge sources the first one wins.
+func mappingPairs(node *yaml.Node) []yamlPair {
+	explicit := make(map[string]bool)
 	for i := 0; i < len(node.Content)-1; i += 2 {
-		keyNode := node.Content[i]
-		valNode := node.Content[i+1]
-		key := keyNode.Value
-		if prefix != "" {
-			key = prefix + "." + key
+		if !isMergeKey(node.Content[i]) {
+			explicit[node.Content[i].Value] = true
 		}
-		if valNode.Kind == yaml.MappingNode {
-			flattenNodeWithComments(key, valNode, result)
-		} else {
-			result[key] = mergeEntry{
-				key:     key,
-				value:   valNode.Value,
-				comment: keyNode.HeadComment,
+	}
+
+	var pairs []yamlPair
+	merged := make(map[string]bool)
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		key, value := node.Content[i], resolveAlias(node.Content[i+1])
+		if !isMergeKey(key) {
+			pairs = append(pairs, yamlPair{key: key, value: value})
+			continue
+		}
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, src := range sources {
+			src = resolveAlias(src)
+			if src.Kind != yaml.MappingNode {
+				continue
+			}
+			for _, p := range mappingPairs(src) {
+				if explicit[p.key.Value] || merged[p.key.Value] {
+					continue
+				}
+				merged[p.key.Value] = true
+				pairs = append(pairs, p)
 			}
 		}
 	}
+	return pairs
+}
+
+// flattenNodeWithComments recursively flattens a yaml.Node tree into
+// dotted keys, preserving HeadComment from leaf key nodes. Sequence
+// elements become indexed keys, keeping the element's own head comment.
+func flattenNodeWithComments(prefix string, node *yaml.Node, result map[string]mergeEntry) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for _, p := range mappingPairs(node) {
+			key := p.key.Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flattenChildWithComments(key, p.key.HeadComment, p.value, result)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			flattenChildWithComments(prefix+"."+strconv.Itoa(i), item.HeadComment, resolveAlias(item), result)
+		}
+	}
+}
+
+// flattenChildWithComments records a leaf value under key, or recurses
+// into a nested mapping or sequence.
+func flattenChildWithComments(key, comment string, valNode *yaml.Node, result map[string]mergeEntry) {
+	if valNode.Kind == yaml.MappingNode || valNode.Kind == yaml.SequenceNode {
+		flattenNodeWithComments(key, valNode, result)
+		return
+	}
+	entry := mergeEntry{
+		key:     key,
+		value:   valNode.Value,
+		comment: comment,
+	}
+	if valNode.Style == 0 && isTypedScalarTag(valNode.ShortTag()) {
+		entry.tag = valNode.ShortTag()
+	}
+	result[key] = entry
+}
+
+// isTypedScalarTag reports whether a YAML short tag denotes a number or
+// boolean, which writeNestedYAML keeps unquoted.
+func isTypedScalarTag(tag string) bool {
+	return tag == "!!int" || tag == "!!float" || tag == "!!bool"
 }
 
 // sortedKeys returns sorted keys of a string map.
@@ -95,6 +208,19 @@ func sortedKeys(m map[string]string) []string {
 	return keys
 }
 
+// namespacedKey converts an i18next-style "namespace:dotted.key" to the
+// flat key under the namespace's top-level node ("namespace.dotted.key").
+// It returns false if s has no namespace or the result is not a valid
+// dotted key.
+func namespacedKey(s string) (string, bool) {
+	ns, rest, ok := strings.Cut(s, ":")
+	if !ok || ns == "" || strings.Contains(ns, ".") {
+		return "", false
+	}
+	key := ns + "." + rest
+	return key, isValidDottedKey(key)
+}
+
 // isValidDottedKey returns true if s looks like a dotted translation key
 // (e.g., "action.refresh", "containerEngine.tabs.general").
 func isValidDottedKey(s string) bool {
@@ -128,6 +254,74 @@ func yamlScalar(s string) string {
 	return strings.TrimRight(string(data), "\n")
 }
 
+// singleQuotedScalar formats a string as a single-quoted YAML scalar.
+// Values containing an apostrophe are double-quoted instead, unless they
+// also contain characters that double quotes would need to escape, in
+// which case the apostrophe is doubled. Multi-line values and values
+// with non-printable characters need a block or escaped double-quoted
+// scalar and are left to yamlScalar.
+func singleQuotedScalar(s string) string {
+	for _, r := range s {
+		if r == '\n' || !unicode.IsPrint(r) {
+			return yamlScalar(s)
+		}
+	}
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	if !strings.ContainsAny(s, `"\`) {
+		return `"` + s + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// foldedScalar formats a long single-line string as a folded block scalar
+// (">-") with body lines of at most width characters where possible. The
+// folding rules turn each line break back into the space it replaced, so
+// lines only break at a single space between two other characters; a
+// word longer than width stays on one line. It reports false for values
+// that need no wrapping or that folding cannot reproduce exactly: multi-line
+// values, values with surrounding whitespace or non-printable characters,
+// and values with no place to break.
+func foldedScalar(s string, width int) (string, bool) {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return "", false
+	}
+	if unicode.IsSpace(runes[0]) || unicode.IsSpace(runes[len(runes)-1]) {
+		return "", false
+	}
+	for _, r := range runes {
+		if r == '\n' || r == '\t' || !unicode.IsPrint(r) {
+			return "", false
+		}
+	}
+
+	var lines []string
+	start, prev := 0, -1
+	cut := func() {
+		lines = append(lines, string(runes[start:prev]))
+		start = prev + 1
+	}
+	for i := 1; i < len(runes)-1; i++ {
+		if runes[i] != ' ' || unicode.IsSpace(runes[i-1]) || unicode.IsSpace(runes[i+1]) {
+			continue
+		}
+		if i-start > width && prev >= start {
+			cut()
+		}
+		prev = i
+	}
+	if len(runes)-start > width && prev >= start {
+		cut()
+	}
+	if len(lines) == 0 {
+		return "", false
+	}
+	lines = append(lines, string(runes[start:]))
+	return ">-\n  " + strings.Join(lines, "\n  "), true
+}
+
 // stripYAMLQuotes removes outer YAML quotes from a value string.
 func stripYAMLQuotes(s string) string {
 	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
@@ -143,12 +337,251 @@ func stripYAMLQuotes(s string) string {
 	return s
 }
 
-// writeNestedYAML writes a sorted slice of mergeEntry items as nested YAML
-// with @reason comments to the given writer. The structure matches en-us.yaml.
-func writeNestedYAML(w *strings.Builder, entries []mergeEntry) {
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].key < entries[j].key
+// loadYAMLKeyOrder loads a YAML file and returns its leaf keys as dotted
+// paths in document order.
+func loadYAMLKeyOrder(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	var order []string
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		collectKeyOrder("", doc.Content[0], &order)
+	}
+	return order, nil
+}
+
+// collectKeyOrder appends the dotted leaf keys of a mapping or sequence
+// node to order, depth first, in the order they appear.
+func collectKeyOrder(prefix string, node *yaml.Node, order *[]string) {
+	var keys []string
+	var values []*yaml.Node
+	switch node.Kind {
+	case yaml.MappingNode:
+		for _, p := range mappingPairs(node) {
+			key := p.key.Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			keys = append(keys, key)
+			values = append(values, p.value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			keys = append(keys, prefix+"."+strconv.Itoa(i))
+			values = append(values, resolveAlias(item))
+		}
+	}
+	for i, key := range keys {
+		if values[i].Kind == yaml.MappingNode || values[i].Kind == yaml.SequenceNode {
+			collectKeyOrder(key, values[i], order)
+		} else {
+			*order = append(*order, key)
+		}
+	}
+}
+
+// keyDefinition is the value of a dotted leaf key and the line that
+// defines it.
+type keyDefinition struct {
+	Value string
+	Line  int
+	Tag   string // resolved YAML short tag, e.g. "!!str" or "!!bool"
+}
+
+// loadYAMLFlatWithLines loads a YAML file and returns each dotted leaf
+// key with its value and source line, for pointing at a key's definition.
+func loadYAMLFlatWithLines(path string) (map[string]keyDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	defs := make(map[string]keyDefinition)
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		collectKeyDefinitions("", doc.Content[0], defs)
+	}
+	return defs, nil
+}
+
+// loadYAMLKeyLines loads a YAML file and returns the source line of each
+// dotted leaf key.
+func loadYAMLKeyLines(path string) (map[string]int, error) {
+	defs, err := loadYAMLFlatWithLines(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := make(map[string]int, len(defs))
+	for k, d := range defs {
+		lines[k] = d.Line
+	}
+	return lines, nil
+}
+
+// collectKeyDefinitions records every leaf key under node with its value
+// and line: the key node's line for mappings, the element's line for
+// sequences.
+func collectKeyDefinitions(prefix string, node *yaml.Node, defs map[string]keyDefinition) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for _, p := range mappingPairs(node) {
+			key := p.key.Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if p.value.Kind == yaml.MappingNode || p.value.Kind == yaml.SequenceNode {
+				collectKeyDefinitions(key, p.value, defs)
+			} else {
+				defs[key] = keyDefinition{Value: p.value.Value, Line: p.key.Line, Tag: p.value.ShortTag()}
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			item = resolveAlias(item)
+			key := prefix + "." + strconv.Itoa(i)
+			if item.Kind == yaml.MappingNode || item.Kind == yaml.SequenceNode {
+				collectKeyDefinitions(key, item, defs)
+			} else {
+				defs[key] = keyDefinition{Value: item.Value, Line: item.Line, Tag: item.ShortTag()}
+			}
+		}
+	}
+}
+
+// sortEntries sorts entries alphabetically by key. When order is non-nil,
+// entries follow the key sequence in order instead; keys absent from order
+// go after their known siblings, alphabetized, so each parent node stays
+// contiguous.
+func sortEntries(entries []mergeEntry, order []string) {
+	if order == nil {
+		sort.Slice(entries, func(i, j int) bool {
+			return keyLess(entries[i].key, entries[j].key)
+		})
+		return
+	}
+
+	// Rank every prefix by the position of the first key under it.
+	rank := make(map[string]int)
+	for i, key := range order {
+		parts := strings.Split(key, ".")
+		for j := range parts {
+			prefix := strings.Join(parts[:j+1], ".")
+			if _, ok := rank[prefix]; !ok {
+				rank[prefix] = i
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a := strings.Split(entries[i].key, ".")
+		b := strings.Split(entries[j].key, ".")
+		for d := 0; d < len(a) && d < len(b); d++ {
+			if a[d] == b[d] {
+				continue
+			}
+			ra, okA := rank[strings.Join(a[:d+1], ".")]
+			rb, okB := rank[strings.Join(b[:d+1], ".")]
+			switch {
+			case okA && okB:
+				return ra < rb
+			case okA != okB:
+				return okA
+			default:
+				return segmentLess(a[d], b[d])
+			}
+		}
+		return len(a) < len(b)
 	})
+}
+
+// keyLess orders dotted keys segment by segment (see segmentLess), so
+// sequence elements sort as key.2 before key.10.
+func keyLess(a, b string) bool {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for d := 0; d < len(as) && d < len(bs); d++ {
+		if as[d] != bs[d] {
+			return segmentLess(as[d], bs[d])
+		}
+	}
+	return len(as) < len(bs)
+}
+
+// segmentLess compares two key segments, numerically when both are
+// sequence indexes and alphabetically otherwise.
+func segmentLess(a, b string) bool {
+	ai, aErr := strconv.Atoi(a)
+	bi, bErr := strconv.Atoi(b)
+	if aErr == nil && bErr == nil {
+		return ai < bi
+	}
+	return a < b
+}
+
+// sequenceParents returns the parent prefixes whose children are exactly
+// the indexes 0..n-1, which writeNestedYAML emits as YAML sequences.
+func sequenceParents(keys []string) map[string]bool {
+	children := make(map[string]map[string]bool)
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		for j := 1; j < len(parts); j++ {
+			parent := strings.Join(parts[:j], ".")
+			if children[parent] == nil {
+				children[parent] = make(map[string]bool)
+			}
+			children[parent][parts[j]] = true
+		}
+	}
+	seqs := make(map[string]bool)
+	for parent, segs := range children {
+		isSeq := true
+		for i := 0; i < len(segs); i++ {
+			if !segs[strconv.Itoa(i)] {
+				isSeq = false
+				break
+			}
+		}
+		if isSeq {
+			seqs[parent] = true
+		}
+	}
+	return seqs
+}
+
+// yamlWriteOptions controls the layout produced by writeNestedYAML.
+type yamlWriteOptions struct {
+	// order, when non-nil, is the key sequence to follow instead of
+	// sorting alphabetically (see sortEntries).
+	order []string
+	// blankDepth is the number of nesting levels separated by blank lines:
+	// 1 (the default when unset) separates top-level groups, 2 also
+	// separates groups within each top-level namespace, and so on.
+	blankDepth int
+	// singleQuotes quotes every string value, preferring single quotes
+	// (see singleQuotedScalar) instead of yamlScalar's minimal quoting.
+	singleQuotes bool
+	// wrap, when positive, writes string values longer than wrap
+	// characters as folded block scalars (see foldedScalar).
+	wrap int
+}
+
+// writeNestedYAML writes a slice of mergeEntry items as nested YAML with
+// @reason comments to the given writer. The structure matches en-us.yaml.
+// Parents whose children are consecutive indexes (key.0, key.1, ...) are
+// written back as YAML sequences.
+func writeNestedYAML(w *strings.Builder, entries []mergeEntry, opts yamlWriteOptions) {
+	sortEntries(entries, opts.order)
+	blankDepth := opts.blankDepth
+	if blankDepth < 1 {
+		blankDepth = 1
+	}
 
 	// Build a map for quick lookup.
 	entryMap := make(map[string]mergeEntry, len(entries))
@@ -157,6 +590,20 @@ func writeNestedYAML(w *strings.Builder, entries []mergeEntry) {
 		entryMap[e.key] = e
 		keys = append(keys, e.key)
 	}
+	seqs := sequenceParents(keys)
+
+	// A sequence element that is itself a mapping or sequence has no line
+	// of its own; its "- " marker goes on the first line written inside it.
+	// pendingDash holds the depths still waiting for that marker.
+	var pendingDash []int
+	writeIndent := func(depth int) {
+		indent := []byte(strings.Repeat("  ", depth))
+		for _, d := range pendingDash {
+			indent[2*d] = '-'
+		}
+		pendingDash = pendingDash[:0]
+		w.Write(indent)
+	}
 
 	var prevParts []string
 	for _, key := range keys {
@@ -177,15 +624,27 @@ func writeNestedYAML(w *strings.Builder, entries []mergeEntry) {
 			}
 		}
 
-		// Add blank line between different top-level groups.
-		if len(prevParts) > 0 && parts[0] != prevParts[0] {
-			w.WriteString("\n")
+		// Add blank line between different top-level groups, and between
+		// nested groups down to blankDepth. Below the top level, adjacent
+		// leaves are not separated; only a change involving a group is.
+		if len(prevParts) > 0 {
+			d := 0
+			for d < len(parts)-1 && d < len(prevParts)-1 && parts[d] == prevParts[d] {
+				d++
+			}
+			isGroup := len(parts)-1 > d || len(prevParts)-1 > d
+			if d < blankDepth && (d == 0 || isGroup) {
+				w.WriteString("\n")
+			}
 		}
 
 		// Emit new parent nodes.
 		for j := common; j < len(parts)-1; j++ {
-			indent := strings.Repeat("  ", j)
-			w.WriteString(indent)
+			if j > 0 && seqs[strings.Join(parts[:j], ".")] {
+				pendingDash = append(pendingDash, j)
+				continue
+			}
+			writeIndent(j)
 			w.WriteString(parts[j])
 			w.WriteString(":\n")
 		}
@@ -196,17 +655,30 @@ func writeNestedYAML(w *strings.Builder, entries []mergeEntry) {
 
 		if e.comment != "" {
 			for _, commentLine := range strings.Split(e.comment, "\n") {
-				w.WriteString(indent)
+				writeIndent(depth)
 				w.WriteString(commentLine)
 				w.WriteString("\n")
 			}
 		}
 
 		leaf := parts[len(parts)-1]
-		w.WriteString(indent)
-		w.WriteString(leaf)
-		w.WriteString(": ")
+		writeIndent(depth)
+		if depth > 0 && seqs[strings.Join(parts[:depth], ".")] {
+			w.WriteString("- ")
+		} else {
+			w.WriteString(leaf)
+			w.WriteString(": ")
+		}
 		scalar := yamlScalar(e.value)
+		if opts.singleQuotes {
+			scalar = singleQuotedScalar(e.value)
+		}
+		if isTypedScalarTag(e.tag) {
+			// Unquoted numbers and booleans in the source keep their type.
+			scalar = e.value
+		} else if folded, ok := foldedScalar(e.value, opts.wrap); ok {
+			scalar = folded
+		}
 		if strings.Contains(scalar, "\n") {
 			// Block scalar (e.g. "|\n  line1\n  line2"): re-indent the body
 			// lines to match the current YAML tree depth.
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -50,6 +51,36 @@ func TestFlattenYAML(t *testing.T) {
 			input:  map[string]interface{}{},
 			want:   map[string]string{},
 		},
+		{
+			name:   "sequence",
+			prefix: "",
+			input: map[string]interface{}{
+				"tips": []interface{}{"first", "second"},
+			},
+			want: map[string]string{"tips.0": "first", "tips.1": "second"},
+		},
+		{
+			name:   "nested sequences and sequence of maps",
+			prefix: "",
+			input: map[string]interface{}{
+				"a": map[string]interface{}{
+					"grid": []interface{}{
+						[]interface{}{"x", "y"},
+					},
+					"steps": []interface{}{
+						map[string]interface{}{"title": "One", "body": "Do this"},
+						map[string]interface{}{"title": "Two"},
+					},
+				},
+			},
+			want: map[string]string{
+				"a.grid.0.0":      "x",
+				"a.grid.0.1":      "y",
+				"a.steps.0.title": "One",
+				"a.steps.0.body":  "Do this",
+				"a.steps.1.title": "Two",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -152,6 +183,31 @@ func TestIsValidDottedKey(t *testing.T) {
 	}
 }
 
+func TestNamespacedKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"common:foo.bar", "common.foo.bar", true},
+		{"common:save", "common.save", true},
+		{"foo.bar", "", false},
+		{":foo.bar", "", false},
+		{"a.b:c", "", false},
+		{"common:", "", false},
+		{"common:foo..bar", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := namespacedKey(tc.input)
+			if ok != tc.ok || (ok && got != tc.want) {
+				t.Errorf("namespacedKey(%q) = %q, %v, want %q, %v", tc.input, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
 func TestLoadYAMLWithComments(t *testing.T) {
 	// Write a temp YAML file with comments and load it.
 	input := `status:
@@ -267,7 +323,7 @@ func TestWriteNestedYAML(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			var buf strings.Builder
-			writeNestedYAML(&buf, tc.entries)
+			writeNestedYAML(&buf, tc.entries, yamlWriteOptions{})
 			got := buf.String()
 			if got != tc.want {
 				t.Errorf("got:\n%s\nwant:\n%s", got, tc.want)
@@ -275,3 +331,346 @@ func TestWriteNestedYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteNestedYAMLSourceOrder(t *testing.T) {
+	entries := []mergeEntry{
+		{key: "a.x", value: "ax"},
+		{key: "b.stale", value: "stale"},
+		{key: "b.z", value: "bz"},
+		{key: "b.a", value: "ba"},
+		{key: "c.only", value: "c"},
+	}
+	order := []string{"b.z", "b.a", "a.x"}
+
+	var buf strings.Builder
+	writeNestedYAML(&buf, entries, yamlWriteOptions{order: order})
+	want := "b:\n  z: bz\n  a: ba\n  stale: stale\n\na:\n  x: ax\n\nc:\n  only: c\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLoadYAMLKeyOrder(t *testing.T) {
+	input := `zeta:
+  b: one
+  a: two
+alpha:
+  nested:
+    y: three
+    x: four
+`
+	tmpFile := t.TempDir() + "/test.yaml"
+	if err := os.WriteFile(tmpFile, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadYAMLKeyOrder(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"zeta.b", "zeta.a", "alpha.nested.y", "alpha.nested.x"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteNestedYAMLSequences(t *testing.T) {
+	input := `a:
+  steps:
+    - body: Do this
+      title: One
+    - title: Two
+  tips:
+    - first
+    # @reason second tip
+    - second
+    - third
+    - fourth
+    - fifth
+    - sixth
+    - seventh
+    - eighth
+    - ninth
+    - tenth
+    - eleventh
+  grid:
+    - - x
+      - "y"
+`
+	tmpFile := t.TempDir() + "/test.yaml"
+	if err := os.WriteFile(tmpFile, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadYAMLWithComments(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := loaded["a.tips.1"]; e.value != "second" || e.comment != "# @reason second tip" {
+		t.Errorf("a.tips.1 = %+v", e)
+	}
+
+	var entries []mergeEntry
+	for _, e := range loaded {
+		entries = append(entries, e)
+	}
+	var buf strings.Builder
+	writeNestedYAML(&buf, entries, yamlWriteOptions{})
+
+	want := `a:
+  grid:
+    - - x
+      - "y"
+  steps:
+    - body: Do this
+      title: One
+    - title: Two
+  tips:
+    - first
+    # @reason second tip
+    - second
+    - third
+    - fourth
+    - fifth
+    - sixth
+    - seventh
+    - eighth
+    - ninth
+    - tenth
+    - eleventh
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The written YAML must flatten back to the same keys.
+	if err := os.WriteFile(tmpFile, []byte(buf.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	flat, err := loadYAMLFlat(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flat) != len(loaded) || flat["a.grid.0.1"] != "y" || flat["a.tips.10"] != "eleventh" {
+		t.Errorf("round trip mismatch: %v", flat)
+	}
+}
+
+func TestWriteNestedYAMLBlankDepth(t *testing.T) {
+	entries := []mergeEntry{
+		{key: "a.x.one", value: "1"},
+		{key: "a.x.two", value: "2"},
+		{key: "a.y.deep.leaf", value: "3"},
+		{key: "a.z", value: "4"},
+		{key: "a.zz", value: "5"},
+		{key: "b.only", value: "6"},
+	}
+
+	tests := []struct {
+		depth int
+		want  string
+	}{
+		{1, "a:\n  x:\n    one: \"1\"\n    two: \"2\"\n  y:\n    deep:\n      leaf: \"3\"\n  z: \"4\"\n  zz: \"5\"\n\nb:\n  only: \"6\"\n"},
+		{2, "a:\n  x:\n    one: \"1\"\n    two: \"2\"\n\n  y:\n    deep:\n      leaf: \"3\"\n\n  z: \"4\"\n  zz: \"5\"\n\nb:\n  only: \"6\"\n"},
+	}
+	for _, tc := range tests {
+		var buf strings.Builder
+		writeNestedYAML(&buf, append([]mergeEntry(nil), entries...), yamlWriteOptions{blankDepth: tc.depth})
+		if got := buf.String(); got != tc.want {
+			t.Errorf("blankDepth %d: got:\n%s\nwant:\n%s", tc.depth, got, tc.want)
+		}
+	}
+}
+
+func TestWriteNestedYAMLPreservesScalarTypes(t *testing.T) {
+	input := `server:
+  port: 8080
+  ratio: 0.5
+  enabled: true
+  quotedPort: "8080"
+  name: Rancher
+`
+	tmpFile := t.TempDir() + "/test.yaml"
+	if err := os.WriteFile(tmpFile, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadYAMLWithComments(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []mergeEntry
+	for _, e := range loaded {
+		entries = append(entries, e)
+	}
+	var buf strings.Builder
+	writeNestedYAML(&buf, entries, yamlWriteOptions{})
+
+	want := `server:
+  enabled: true
+  name: Rancher
+  port: 8080
+  quotedPort: "8080"
+  ratio: 0.5
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFoldedScalar(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+		ok    bool
+	}{
+		{"short", "Short value", 20, "", false},
+		{"disabled", "Some value that is long", 0, "", false},
+		{"wrapped", "The quick brown fox jumps over the lazy dog", 15, ">-\n  The quick brown\n  fox jumps over\n  the lazy dog", true},
+		{"long word", "Supercalifragilistic word", 10, ">-\n  Supercalifragilistic\n  word", true},
+		{"no break", "https://example.com/a/very/long/path", 10, "", false},
+		{"double space kept", "One  two three four", 8, ">-\n  One  two\n  three\n  four", true},
+		{"multi-line", "First line\nsecond line is longer", 10, "", false},
+		{"leading space", " Leading space value", 10, "", false},
+		{"trailing space", "Trailing space value ", 10, "", false},
+		{"tab", "Tab\tseparated value", 10, "", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := foldedScalar(tc.input, tc.width)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("foldedScalar(%q, %d) = %q, %v; want %q, %v", tc.input, tc.width, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestWriteNestedYAMLWrap(t *testing.T) {
+	values := map[string]string{
+		"diag.long":    "The container engine could not be started because the virtual machine failed to boot: see the logs for details.",
+		"diag.special": "Value with: colons, #hashes, 'quotes', \"double quotes\", {placeholders} and  two spaces, then more text.",
+		"diag.short":   "Short",
+		"diag.lines":   "First line\nSecond line that is also rather long for one line",
+	}
+	var entries []mergeEntry
+	for k, v := range values {
+		entries = append(entries, mergeEntry{key: k, value: v})
+	}
+	var buf strings.Builder
+	writeNestedYAML(&buf, entries, yamlWriteOptions{wrap: 30})
+	out := buf.String()
+	if !strings.Contains(out, "  long: >-\n    The container engine could not\n") {
+		t.Errorf("long value not folded:\n%s", out)
+	}
+	if !strings.Contains(out, "  short: Short\n") {
+		t.Errorf("short value changed:\n%s", out)
+	}
+
+	tmpFile := t.TempDir() + "/test.yaml"
+	if err := os.WriteFile(tmpFile, []byte(out), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadYAMLFlat(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range values {
+		if loaded[k] != v {
+			t.Errorf("%s: round trip got %q, want %q", k, loaded[k], v)
+		}
+	}
+}
+
+func TestLoadYAMLKeyLines(t *testing.T) {
+	tmpFile := t.TempDir() + "/test.yaml"
+	content := "nav:\n  home: Home\n\n  about: About\ntips:\n  - First\n  - Second\n"
+	os.WriteFile(tmpFile, []byte(content), 0644)
+
+	got, err := loadYAMLKeyLines(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"nav.home": 2, "nav.about": 4, "tips.0": 6, "tips.1": 7}
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for k, line := range want {
+		if got[k] != line {
+			t.Errorf("%s: line %d, want %d", k, got[k], line)
+		}
+	}
+}
+
+func TestLoadYAMLFlatWithLines(t *testing.T) {
+	tmpFile := t.TempDir() + "/test.yaml"
+	content := "nav:\n  # Shown in the sidebar.\n  home: Home\ntips:\n  - First\n  - true\n"
+	os.WriteFile(tmpFile, []byte(content), 0644)
+
+	got, err := loadYAMLFlatWithLines(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]keyDefinition{
+		"nav.home": {Value: "Home", Line: 3, Tag: "!!str"},
+		"tips.0":   {Value: "First", Line: 5, Tag: "!!str"},
+		"tips.1":   {Value: "true", Line: 6, Tag: "!!bool"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFlattenAnchorsAndAliases(t *testing.T) {
+	tmpFile := t.TempDir() + "/test.yaml"
+	content := `common: &common
+  ok: OK
+  cancel: Cancel
+dialog:
+  buttons: *common
+  title: Confirm
+form:
+  <<: *common
+  cancel: Discard
+  save: Save
+`
+	os.WriteFile(tmpFile, []byte(content), 0644)
+
+	want := map[string]string{
+		"common.ok":             "OK",
+		"common.cancel":         "Cancel",
+		"dialog.buttons.ok":     "OK",
+		"dialog.buttons.cancel": "Cancel",
+		"dialog.title":          "Confirm",
+		"form.ok":               "OK",
+		"form.cancel":           "Discard",
+		"form.save":             "Save",
+	}
+
+	flat, err := loadYAMLFlat(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(flat, want) {
+		t.Errorf("loadYAMLFlat: got %v, want %v", flat, want)
+	}
+
+	entries, err := loadYAMLWithComments(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string, len(entries))
+	for k, e := range entries {
+		got[k] = e.value
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadYAMLWithComments: got %v, want %v", got, want)
+	}
+
+	order, err := loadYAMLKeyOrder(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantOrder := []string{"common.ok", "common.cancel", "dialog.buttons.ok", "dialog.buttons.cancel", "dialog.title", "form.ok", "form.cancel", "form.save"}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("loadYAMLKeyOrder: got %v, want %v", order, wantOrder)
+	}
+}